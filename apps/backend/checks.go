@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"backend/internal/delivery/http/routes"
+	"backend/internal/health"
+)
+
+// workerState is what the readiness probe reports for one background worker.
+type workerState struct {
+	State     string     `json:"state"`
+	LastRunAt *time.Time `json:"lastRunAt,omitempty"`
+	NextRunAt *time.Time `json:"nextRunAt,omitempty"`
+	LastError string     `json:"lastError,omitempty"`
+}
+
+func newWorkerState(started, running bool, last, next *time.Time, lastError string) workerState {
+	state := "idle"
+	switch {
+	case !started:
+		state = "pending"
+	case running:
+		state = "running"
+	}
+	return workerState{State: state, LastRunAt: last, NextRunAt: next, LastError: lastError}
+}
+
+// workersCheck reports the state of every enabled worker. It fails while the
+// workers wait for MongoDB and when the last run of any of them failed.
+func workersCheck(bg routes.Workers, started *atomic.Bool) health.CheckFunc {
+	return func(context.Context) (any, error) {
+		isStarted := started.Load()
+		states := map[string]workerState{}
+		if bg.Refresher != nil {
+			p := bg.Refresher.Progress()
+			states["refresher"] = newWorkerState(isStarted, p.Running, p.LastPassAt, p.NextPassAt, p.LastError)
+		}
+		if bg.ChangesSync != nil {
+			p := bg.ChangesSync.Progress()
+			states["changesSync"] = newWorkerState(isStarted, p.Running, p.LastRunAt, p.NextRunAt, p.LastError)
+		}
+		if bg.Purger != nil {
+			p := bg.Purger.Progress()
+			states["purger"] = newWorkerState(isStarted, p.Running, p.LastPassAt, p.NextPassAt, p.LastError)
+		}
+
+		if len(states) > 0 && !isStarted {
+			return states, errors.New("workers start once the database is reachable")
+		}
+		var failing []string
+		for name, state := range states {
+			if state.LastError != "" {
+				failing = append(failing, name)
+			}
+		}
+		if len(failing) > 0 {
+			slices.Sort(failing)
+			return states, fmt.Errorf("last run failed: %s", strings.Join(failing, ", "))
+		}
+		return states, nil
+	}
+}
@@ -0,0 +1,124 @@
+// Command import hydrates the local catalog from a TMDB daily ID export
+// file (e.g. movie_ids_05_15_2025.json.gz) downloaded to local disk.
+//
+//	go run ./cmd/import -file movie_ids_05_15_2025.json.gz -type movie -min-popularity 5
+//
+// Titles go to the storage STORAGE_DRIVER selects, mongo or sqlite; the
+// memory driver is refused as nothing would outlive the run. Progress is
+// checkpointed in the same storage, so rerunning the same command after an
+// interruption resumes where the previous run stopped.
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"backend/config"
+	"backend/database"
+	"backend/internal/domain"
+	"backend/internal/infrastructure/migrations"
+	"backend/internal/infrastructure/repository"
+	"backend/internal/infrastructure/repository/sqlite"
+	"backend/internal/infrastructure/service"
+	"backend/internal/logging"
+	"backend/internal/worker"
+)
+
+func main() {
+	file := flag.String("file", "", "path to the gzipped TMDB daily ID export")
+	mediaType := flag.String("type", "movie", "export type: movie or tv")
+	minPopularity := flag.Float64("min-popularity", 1, "skip titles less popular than this")
+	includeAdult := flag.Bool("include-adult", false, "import titles flagged as adult")
+	concurrency := flag.Int("concurrency", 4, "maximum concurrent TMDB fetches")
+	rateLimit := flag.Float64("rate", 20, "TMDB requests per second")
+	batchSize := flag.Int("batch", 500, "export lines per checkpoint")
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Deferred first so it runs after the storage has been closed
+	exitCode := 0
+	defer func() { os.Exit(exitCode) }()
+
+	cfg := config.Load()
+	slog.SetDefault(logging.New(os.Stderr, cfg.LogFormat, cfg.Runtime().LogLevel))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var repos domain.Repositories
+	switch cfg.StorageDriver {
+	case "mongo":
+		connectCtx, cancelConnect := context.WithTimeout(ctx, 10*time.Second)
+		conn, err := database.Connect(connectCtx, cfg)
+		cancelConnect()
+		if err != nil {
+			fatal("Failed to connect to database", "error", err)
+		}
+		defer conn.Close()
+		db := conn.Database()
+
+		status, err := migrations.NewRunner(db).Status(ctx)
+		if err != nil {
+			fatal("Schema check failed", "error", err)
+		}
+		if len(status.Pending) > 0 {
+			fatal("Schema migrations pending, run `go run ./cmd/migrate up` first", "count", len(status.Pending))
+		}
+		repos = repository.New(db)
+	case "sqlite":
+		sqliteDB, err := sqlite.Open(cfg.SQLitePath)
+		if err != nil {
+			fatal("Failed to open SQLite database", "path", cfg.SQLitePath, "error", err)
+		}
+		defer sqliteDB.Close()
+
+		// Same as the API server, which brings the embedded schema up to
+		// date on start
+		if _, err := sqlite.Migrate(ctx, sqliteDB); err != nil {
+			fatal("Schema migration failed", "error", err)
+		}
+		repos = sqlite.New(sqliteDB)
+	case "memory":
+		fatal("The memory storage driver keeps nothing after the import exits; set STORAGE_DRIVER to mongo or sqlite")
+	default:
+		fatal("Unknown storage driver", "driver", cfg.StorageDriver)
+	}
+
+	importer := worker.NewExportImporter(
+		repos.Movies,
+		repos.TVShows,
+		repos.Checkpoints,
+		service.NewTMDBService(cfg),
+		worker.ExportImportConfig{
+			MediaType:     *mediaType,
+			MinPopularity: *minPopularity,
+			IncludeAdult:  *includeAdult,
+			Concurrency:   *concurrency,
+			RateLimit:     *rateLimit,
+			BatchSize:     *batchSize,
+		},
+	)
+
+	stats, err := importer.Import(ctx, *file)
+	if err != nil {
+		slog.Error("Import stopped", "error", err, "stats", stats)
+		exitCode = 1
+		return
+	}
+	slog.Info("Import finished", "stats", stats)
+}
+
+// fatal logs msg at error level and exits.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
@@ -0,0 +1,89 @@
+// Command migrate inspects and applies MongoDB schema migrations.
+//
+//	go run ./cmd/migrate status
+//	go run ./cmd/migrate up
+//
+// It only works with STORAGE_DRIVER=mongo. The SQLite schema is embedded and
+// brought up to date whenever the API server or the import command opens the
+// file, and the memory driver has no schema.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"backend/config"
+	"backend/database"
+	"backend/internal/infrastructure/migrations"
+	"backend/internal/logging"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: migrate [status|up]")
+	}
+	flag.Parse()
+
+	command := flag.Arg(0)
+	if command == "" {
+		command = "status"
+	}
+	if command != "status" && command != "up" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Deferred first so it runs after the connection has been closed
+	exitCode := 0
+	defer func() { os.Exit(exitCode) }()
+
+	cfg := config.Load()
+	slog.SetDefault(logging.New(os.Stderr, cfg.LogFormat, cfg.Runtime().LogLevel))
+
+	if cfg.StorageDriver != "mongo" {
+		fatal("Schema migrations only apply to MongoDB; SQLite is migrated whenever it is opened",
+			"driver", cfg.StorageDriver)
+	}
+
+	connectCtx, cancelConnect := context.WithTimeout(context.Background(), 10*time.Second)
+	conn, err := database.Connect(connectCtx, cfg)
+	cancelConnect()
+	if err != nil {
+		fatal("Failed to connect to database", "error", err)
+	}
+	defer conn.Close()
+	db := conn.Database()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	runner := migrations.NewRunner(db)
+	var status *migrations.Status
+	if command == "up" {
+		status, err = runner.Up(ctx)
+	} else {
+		status, err = runner.Status(ctx)
+	}
+
+	if status != nil {
+		out, _ := json.MarshalIndent(status, "", "  ")
+		fmt.Println(string(out))
+	}
+	if err != nil {
+		slog.Error("Migration failed", "command", command, "error", err)
+		exitCode = 1
+	}
+}
+
+// fatal logs msg at error level and exits.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
@@ -0,0 +1,56 @@
+// Command tmdbfake serves a fake TMDB API for offline development. Point
+// TMDB_BASE_URL at it.
+//
+//	go run ./cmd/tmdbfake
+//	go run ./cmd/tmdbfake -fixtures ./tmdb-fixtures -latency 300ms -rate-limit 0.2
+//	go run ./cmd/tmdbfake -fixtures ./tmdb-fixtures -record
+//
+// Record mode relays every request to the real API with TMDB_API_KEY and
+// saves the answers as fixtures.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"backend/config"
+	"backend/internal/infrastructure/service/tmdbfake"
+)
+
+func main() {
+	addr := flag.String("addr", ":8090", "listen address")
+	dir := flag.String("fixtures", "", "fixtures directory (default: the built-in set)")
+	record := flag.Bool("record", false, "record real TMDB answers into the fixtures directory")
+	upstream := flag.String("upstream", "https://api.themoviedb.org/3", "TMDB API base URL to record from")
+	latency := flag.Duration("latency", 0, "delay every answer")
+	rateLimit := flag.Float64("rate-limit", 0, "share of requests answered with 429")
+	serverErrors := flag.Float64("server-errors", 0, "share of requests answered with 500")
+	retryAfter := flag.Duration("retry-after", 0, "Retry-After sent with 429s")
+	seed := flag.Int64("seed", 1, "seed for the random failures")
+	flag.Parse()
+
+	cfg := tmdbfake.Config{
+		Dir:      *dir,
+		Record:   *record,
+		Upstream: *upstream,
+		Seed:     *seed,
+		Scenario: tmdbfake.Scenario{
+			Latency:         *latency,
+			RateLimitRate:   *rateLimit,
+			ServerErrorRate: *serverErrors,
+			RetryAfter:      *retryAfter,
+		},
+	}
+	if *record {
+		cfg.APIKey = config.Load().TMDBAPIKey
+	}
+
+	fake, err := tmdbfake.New(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Fake TMDB API listening on %s, scenario at %s", *addr, tmdbfake.ScenarioPath)
+	log.Fatal(http.ListenAndServe(*addr, fake))
+}
@@ -1,12 +1,17 @@
 package config
 
 import (
+	"errors"
+	"flag"
 	"log"
-	"os"
-
-	"github.com/joho/godotenv"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// Config is the application configuration. Every setting is named after its
+// environment variable and resolved from, in increasing precedence, built-in
+// defaults, the config file, the environment and command-line flags.
 type Config struct {
 	Port        string
 	MongoURI    string
@@ -14,27 +19,222 @@ type Config struct {
 	JWTSecret   string
 	TMDBAPIKey  string
 	TMDBBaseURL string
+	AdminToken  string
+
+	// RateLimitEnabled turns on per-client limits, set in RATE_LIMITS
+	RateLimitEnabled bool
+	// APIKeys are the keys clients may send in X-API-Key to be rate limited
+	// apart from others behind the same address
+	APIKeys []string
+	// TrustedProxies lists the proxy addresses or CIDR ranges whose
+	// X-Forwarded-For is believed when working out a client's IP
+	TrustedProxies []string
+
+	// LogFormat is "json" or "text"
+	LogFormat string
+
+	// MongoDatabase is the database to use, taken from MONGO_DATABASE or
+	// else the path of MONGO_URI
+	MongoDatabase string
+
+	// MongoDB client options. Zero values, and booleans set nowhere, keep
+	// what MONGO_URI says or the driver default.
+	MongoMinPoolSize            int
+	MongoMaxPoolSize            int
+	MongoMaxConnIdleTime        time.Duration
+	MongoConnectTimeout         time.Duration
+	MongoServerSelectionTimeout time.Duration
+	MongoSocketTimeout          time.Duration
+	// MongoReadPreference is a mode such as primary or secondaryPreferred
+	MongoReadPreference string
+	// MongoWriteConcern is "majority", a node count or a tag set name
+	MongoWriteConcern string
+	MongoWriteJournal bool
+	MongoWriteTimeout time.Duration
+	MongoRetryWrites  bool
+	MongoRetryReads   bool
+	MongoTLSCAFile    string
+	MongoTLSCertFile  string
+	MongoTLSKeyFile   string
+	// MongoCompressors lists wire compressors in order of preference:
+	// zstd, snappy or zlib
+	MongoCompressors []string
+
+	// TMDB requests failing with 429, 5xx or a network error are retried
+	TMDBMaxRetries   int
+	TMDBRetryBackoff time.Duration
+
+	// OpenTelemetry tracing. TracesExporter is "otlp", "stdout" or "none";
+	// OTLPEndpoint defaults to the exporter's own, http://localhost:4318
+	TracesExporter   string
+	OTLPEndpoint     string
+	ServiceName      string
+	TraceSampleRatio float64
+
+	// StorageDriver selects the repository backend: "mongo", "sqlite" or
+	// "memory"
+	StorageDriver string
+	SQLitePath    string
+
+	// MigrateOnStartup applies pending schema migrations before serving
+	MigrateOnStartup bool
+
+	// While MongoDB is unreachable the API runs in degraded mode and retries
+	// with exponential backoff between these bounds
+	MongoReconnectInterval    time.Duration
+	MongoReconnectMaxInterval time.Duration
+
+	// Background catalog refresh
+	RefreshEnabled    bool
+	RefreshInterval   time.Duration
+	RefreshStaleAfter time.Duration
+	RefreshRateLimit  float64
+	RefreshBatchSize  int
+
+	// Incremental sync from the TMDB changes feed
+	SyncEnabled         bool
+	SyncInterval        time.Duration
+	SyncInitialLookback time.Duration
+	SyncRateLimit       float64
+
+	// Hard deletion of soft-deleted records
+	PurgeEnabled   bool
+	PurgeInterval  time.Duration
+	PurgeRetention time.Duration
+
+	// settings records where each value came from, for String
+	settings []setting
+
+	runtime  atomic.Pointer[Runtime]
+	reloadMu sync.Mutex
+	reload   ReloadStatus
 }
 
+// Load reads the configuration from the config file and the environment and
+// exits when it is invalid. Commands with flags of their own use it; the API
+// server uses LoadArgs.
 func Load() *Config {
-	// Load .env file if it exists
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found")
+	cfg, err := LoadArgs(nil)
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	return cfg
+}
+
+// LoadArgs reads the configuration from the config file, the environment and
+// args, then validates it. Any setting can be given as a flag named after its
+// environment variable in kebab case, e.g. --tmdb-max-retries=3 for
+// TMDB_MAX_RETRIES. The config file is --config, CONFIG_FILE or else .env;
+// files ending in .json hold a JSON object, anything else uses .env syntax.
+// --help returns flag.ErrHelp after printing every setting.
+func LoadArgs(args []string) (*Config, error) {
+	l, err := newLoader(args)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		Port:        l.string("PORT", "8080"),
+		MongoURI:    l.uri("MONGO_URI", "mongodb://localhost:27017/movies_platform"),
+		Environment: l.string("ENVIRONMENT", "development"),
+		JWTSecret:   l.secret("JWT_SECRET", defaultJWTSecret),
+		TMDBAPIKey:  l.secret("TMDB_API_KEY", defaultTMDBAPIKey),
+		TMDBBaseURL: l.string("TMDB_BASE_URL", "https://api.themoviedb.org/3"),
+		AdminToken:  l.secret("ADMIN_TOKEN", ""),
+
+		RateLimitEnabled: l.bool("RATE_LIMIT_ENABLED", true),
+		APIKeys:          l.secretList("API_KEYS", ""),
+		TrustedProxies:   l.list("TRUSTED_PROXIES", ""),
+
+		LogFormat: l.string("LOG_FORMAT", "json"),
+
+		MongoDatabase: l.string("MONGO_DATABASE", ""),
+
+		MongoMinPoolSize:            l.int("MONGO_MIN_POOL_SIZE", 0, 0),
+		MongoMaxPoolSize:            l.int("MONGO_MAX_POOL_SIZE", 0, 0),
+		MongoMaxConnIdleTime:        l.duration("MONGO_MAX_CONN_IDLE_TIME", 0, 0),
+		MongoConnectTimeout:         l.duration("MONGO_CONNECT_TIMEOUT", 0, 0),
+		MongoServerSelectionTimeout: l.duration("MONGO_SERVER_SELECTION_TIMEOUT", 0, 0),
+		MongoSocketTimeout:          l.duration("MONGO_SOCKET_TIMEOUT", 0, 0),
+		MongoReadPreference:         l.string("MONGO_READ_PREFERENCE", ""),
+		MongoWriteConcern:           l.string("MONGO_WRITE_CONCERN", ""),
+		MongoWriteJournal:           l.bool("MONGO_WRITE_JOURNAL", false),
+		MongoWriteTimeout:           l.duration("MONGO_WRITE_TIMEOUT", 0, 0),
+		MongoRetryWrites:            l.bool("MONGO_RETRY_WRITES", true),
+		MongoRetryReads:             l.bool("MONGO_RETRY_READS", true),
+		MongoTLSCAFile:              l.string("MONGO_TLS_CA_FILE", ""),
+		MongoTLSCertFile:            l.string("MONGO_TLS_CERT_FILE", ""),
+		MongoTLSKeyFile:             l.string("MONGO_TLS_KEY_FILE", ""),
+		MongoCompressors:            l.list("MONGO_COMPRESSORS", ""),
+
+		TMDBMaxRetries:   l.int("TMDB_MAX_RETRIES", 2, 0),
+		TMDBRetryBackoff: l.duration("TMDB_RETRY_BACKOFF", 500*time.Millisecond, time.Millisecond),
+
+		TracesExporter:   l.string("OTEL_TRACES_EXPORTER", "none"),
+		OTLPEndpoint:     l.uri("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+		ServiceName:      l.string("OTEL_SERVICE_NAME", "movies-api"),
+		TraceSampleRatio: l.float("OTEL_TRACES_SAMPLE_RATIO", 1, 0),
+
+		StorageDriver: l.string("STORAGE_DRIVER", "mongo"),
+		SQLitePath:    l.string("SQLITE_PATH", "movies.db"),
+
+		MigrateOnStartup: l.bool("MIGRATE_ON_STARTUP", true),
+
+		MongoReconnectInterval:    l.duration("MONGO_RECONNECT_INTERVAL", 2*time.Second, 100*time.Millisecond),
+		MongoReconnectMaxInterval: l.duration("MONGO_RECONNECT_MAX_INTERVAL", 30*time.Second, 100*time.Millisecond),
+
+		RefreshEnabled:    l.bool("REFRESH_ENABLED", true),
+		RefreshInterval:   l.duration("REFRESH_INTERVAL", 15*time.Minute, time.Second),
+		RefreshStaleAfter: l.duration("REFRESH_STALE_AFTER", 24*time.Hour, time.Minute),
+		RefreshRateLimit:  l.float("REFRESH_RATE_LIMIT", 2, 0.01),
+		RefreshBatchSize:  l.int("REFRESH_BATCH_SIZE", 50, 1),
+
+		SyncEnabled:         l.bool("SYNC_ENABLED", true),
+		SyncInterval:        l.duration("SYNC_INTERVAL", time.Hour, time.Second),
+		SyncInitialLookback: l.duration("SYNC_INITIAL_LOOKBACK", 24*time.Hour, time.Minute),
+		SyncRateLimit:       l.float("SYNC_RATE_LIMIT", 2, 0.01),
+
+		PurgeEnabled:   l.bool("PURGE_ENABLED", true),
+		PurgeInterval:  l.duration("PURGE_INTERVAL", 24*time.Hour, time.Minute),
+		PurgeRetention: l.duration("PURGE_RETENTION", 30*24*time.Hour, time.Hour),
+	}
+
+	rt := loadRuntime(l)
+	rt.Version, rt.LoadedAt = 1, time.Now()
+	cfg.runtime.Store(rt)
+
+	loadErr := l.finish()
+	if errors.Is(loadErr, flag.ErrHelp) {
+		return nil, loadErr
 	}
+	cfg.settings = l.settings
+
+	if cfg.MongoDatabase == "" {
+		cfg.MongoDatabase = databaseFromURI(cfg.MongoURI)
+		cfg.setSource("MONGO_DATABASE", cfg.MongoDatabase, "uri")
+	}
+
+	if err := errors.Join(loadErr, cfg.Validate()); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
 
-	return &Config{
-		Port:        getEnv("PORT", "8080"),
-		MongoURI:    getEnv("MONGO_URI", "mongodb://localhost:27017/movies_platform"),
-		Environment: getEnv("ENVIRONMENT", "development"),
-		JWTSecret:   getEnv("JWT_SECRET", "your-secret-key"),
-		TMDBAPIKey:  getEnv("TMDB_API_KEY", "e2cadf69ee384867df4db7959f1eee53"),
-		TMDBBaseURL: getEnv("TMDB_BASE_URL", "https://api.themoviedb.org/3"),
+// Source reports where the value of the setting named key came from:
+// "default", "file", "env", "flag" or, for MONGO_DATABASE, "uri".
+func (c *Config) Source(key string) string {
+	for _, s := range c.settings {
+		if s.key == key {
+			return s.source
+		}
 	}
+	return ""
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+func (c *Config) setSource(key, value, source string) {
+	for i := range c.settings {
+		if c.settings[i].key == key {
+			c.settings[i].value, c.settings[i].source = value, source
+		}
 	}
-	return defaultValue
 }
@@ -0,0 +1,246 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Runtime holds the settings that can change without a restart. Readers
+// take a snapshot with Config.Runtime and must not modify it.
+type Runtime struct {
+	// Version counts successful loads, starting at 1.
+	Version  int       `json:"version"`
+	LoadedAt time.Time `json:"loadedAt"`
+
+	CORS     CORSPolicy `json:"cors"`
+	LogLevel slog.Level `json:"logLevel"`
+
+	// TMDBCacheTTL is how long TMDB answers are reused, zero to disable
+	TMDBCacheTTL time.Duration `json:"tmdbCacheTtl"`
+	// TMDBRateLimit caps TMDB requests per second, zero for no limit
+	TMDBRateLimit float64 `json:"tmdbRateLimit"`
+
+	// RateLimits caps requests per client for each route group
+	RateLimits map[string]RateLimit `json:"rateLimits"`
+}
+
+// CORSPolicy says which browser origins may call the API and how.
+type CORSPolicy struct {
+	// AllowedOrigins are origins such as https://example.com, patterns
+	// such as https://*.example.com matching any subdomain, or "*" for any
+	AllowedOrigins []string `json:"allowedOrigins"`
+	AllowedMethods []string `json:"allowedMethods"`
+	AllowedHeaders []string `json:"allowedHeaders"`
+	// ExposedHeaders are the response headers scripts may read
+	ExposedHeaders []string `json:"exposedHeaders"`
+	// AllowCredentials lets browsers send cookies and authorization; it
+	// can't be combined with "*"
+	AllowCredentials bool `json:"allowCredentials"`
+	// MaxAge is how long browsers may cache a preflight answer
+	MaxAge time.Duration `json:"maxAge"`
+}
+
+// RateLimit lets a client make Requests per Period, all at once at most.
+type RateLimit struct {
+	Requests int           `json:"requests"`
+	Period   time.Duration `json:"period"`
+}
+
+// rateLimitGroups are the route groups limits can be set for: every API
+// request counts against default, searches against search as well.
+var rateLimitGroups = map[string]bool{"default": true, "search": true}
+
+// ReloadStatus describes the settings in effect and the last reload.
+type ReloadStatus struct {
+	Runtime      *Runtime   `json:"runtime"`
+	LastReloadAt *time.Time `json:"lastReloadAt,omitempty"`
+	LastError    string     `json:"lastError,omitempty"`
+	// Restart lists settings changed on disk that only apply after a restart
+	Restart []string `json:"restart,omitempty"`
+}
+
+// reloadableKeys are the settings Runtime is built from.
+var reloadableKeys = map[string]bool{
+	"CORS_ALLOWED_ORIGINS":   true,
+	"CORS_ALLOWED_METHODS":   true,
+	"CORS_ALLOWED_HEADERS":   true,
+	"CORS_EXPOSED_HEADERS":   true,
+	"CORS_ALLOW_CREDENTIALS": true,
+	"CORS_MAX_AGE":           true,
+	"LOG_LEVEL":              true,
+	"RATE_LIMITS":            true,
+	"TMDB_CACHE_TTL":         true,
+	"TMDB_RATE_LIMIT":        true,
+}
+
+func loadRuntime(l *loader) *Runtime {
+	rt := &Runtime{
+		CORS: CORSPolicy{
+			AllowedOrigins:   l.list("CORS_ALLOWED_ORIGINS", "*"),
+			AllowedMethods:   l.list("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS"),
+			AllowedHeaders:   l.list("CORS_ALLOWED_HEADERS", "Accept,Authorization,Cache-Control,Content-Type,If-Match,X-API-Key,X-Request-ID,X-Requested-With,traceparent,tracestate"),
+			ExposedHeaders:   l.list("CORS_EXPOSED_HEADERS", "ETag,X-Request-ID,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset,RateLimit-Policy,Retry-After"),
+			AllowCredentials: l.bool("CORS_ALLOW_CREDENTIALS", false),
+			MaxAge:           l.duration("CORS_MAX_AGE", 10*time.Minute, 0),
+		},
+		TMDBCacheTTL:  l.duration("TMDB_CACHE_TTL", 5*time.Minute, 0),
+		TMDBRateLimit: l.float("TMDB_RATE_LIMIT", 0, 0),
+		RateLimits:    map[string]RateLimit{},
+	}
+
+	for _, entry := range l.list("RATE_LIMITS", "default:300/m,search:30/m") {
+		group, limit, err := parseRateLimit(entry)
+		if err != nil {
+			l.fail("RATE_LIMITS: %v", err)
+			continue
+		}
+		rt.RateLimits[group] = limit
+	}
+
+	raw := l.string("LOG_LEVEL", "info")
+	if err := rt.LogLevel.UnmarshalText([]byte(raw)); err != nil {
+		l.fail("LOG_LEVEL: %q is not one of debug, info, warn or error", raw)
+	}
+	return rt
+}
+
+func (rt *Runtime) validate() error {
+	var errs []error
+	for _, origin := range rt.CORS.AllowedOrigins {
+		if origin == "*" {
+			if rt.CORS.AllowCredentials {
+				errs = append(errs, errors.New("CORS_ALLOW_CREDENTIALS can't be combined with CORS_ALLOWED_ORIGINS=*; list the origins instead"))
+			}
+			continue
+		}
+		u, err := url.Parse(origin)
+		valid := err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Path == ""
+		if valid {
+			host := strings.TrimPrefix(u.Host, "*.")
+			valid = host != "" && !strings.Contains(host, "*")
+		}
+		if !valid {
+			errs = append(errs, fmt.Errorf("CORS_ALLOWED_ORIGINS: %q is not an origin such as https://example.com or https://*.example.com", origin))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Runtime returns the reloadable settings currently in effect.
+func (c *Config) Runtime() *Runtime {
+	return c.runtime.Load()
+}
+
+// Reload reads the configuration again with args and, when it is valid,
+// swaps in its reloadable settings. Settings that changed but need a restart
+// are left alone and reported. An invalid configuration keeps the current
+// one in effect.
+func (c *Config) Reload(args []string) (ReloadStatus, error) {
+	next, err := LoadArgs(args)
+
+	c.reloadMu.Lock()
+	defer c.reloadMu.Unlock()
+
+	now := time.Now()
+	c.reload.LastReloadAt = &now
+	if err != nil {
+		c.reload.LastError = err.Error()
+		return c.reloadStatus(), err
+	}
+
+	current := c.runtime.Load()
+	rt := next.runtime.Load()
+	rt.Version = current.Version + 1
+	c.runtime.Store(rt)
+
+	c.reload.LastError = ""
+	c.reload.Restart = c.restartNeeded(next)
+	return c.reloadStatus(), nil
+}
+
+// ReloadStatus reports the settings in effect and how the last reload went.
+func (c *Config) ReloadStatus() ReloadStatus {
+	c.reloadMu.Lock()
+	defer c.reloadMu.Unlock()
+	return c.reloadStatus()
+}
+
+func (c *Config) reloadStatus() ReloadStatus {
+	status := c.reload
+	status.Runtime = c.runtime.Load()
+	return status
+}
+
+// restartNeeded lists the settings other than the reloadable ones whose
+// value differs in next.
+func (c *Config) restartNeeded(next *Config) []string {
+	current := make(map[string]string, len(c.settings))
+	for _, s := range c.settings {
+		current[s.key] = s.value
+	}
+
+	var keys []string
+	for _, s := range next.settings {
+		if !reloadableKeys[s.key] && current[s.key] != s.value {
+			keys = append(keys, s.key)
+		}
+	}
+	return keys
+}
+
+// parseRateLimit reads "group:requests/period", where period is s, m, h or
+// a duration such as 10s.
+func parseRateLimit(entry string) (string, RateLimit, error) {
+	invalid := fmt.Errorf("%q is not a limit such as search:30/m", entry)
+	group, rest, ok := strings.Cut(entry, ":")
+	if !ok {
+		return "", RateLimit{}, invalid
+	}
+	group = strings.TrimSpace(group)
+	if !rateLimitGroups[group] {
+		return "", RateLimit{}, fmt.Errorf("unknown group %q (want default or search)", group)
+	}
+	requests, period, ok := strings.Cut(rest, "/")
+	if !ok {
+		return "", RateLimit{}, invalid
+	}
+
+	var limit RateLimit
+	var err error
+	if limit.Requests, err = strconv.Atoi(strings.TrimSpace(requests)); err != nil || limit.Requests < 1 {
+		return "", RateLimit{}, invalid
+	}
+	period = strings.TrimSpace(period)
+	if period == "s" || period == "m" || period == "h" {
+		period = "1" + period
+	}
+	if limit.Period, err = time.ParseDuration(period); err != nil || limit.Period <= 0 {
+		return "", RateLimit{}, invalid
+	}
+	return group, limit, nil
+}
+
+// list reads a comma-separated setting, dropping blanks.
+func (l *loader) list(key, def string) []string {
+	return splitList(l.string(key, def))
+}
+
+// secretList is list for settings that must not be logged.
+func (l *loader) secretList(key, def string) []string {
+	return splitList(l.secret(key, def))
+}
+
+func splitList(raw string) []string {
+	var values []string
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+)
+
+const (
+	// defaultTMDBAPIKey is a shared development key; production must bring
+	// its own
+	defaultTMDBAPIKey = "e2cadf69ee384867df4db7959f1eee53"
+	defaultJWTSecret  = "your-secret-key"
+
+	// defaultDatabase is used when neither MONGO_DATABASE nor the URI names
+	// a database
+	defaultDatabase = "monorepo"
+
+	minJWTSecretLength = 32
+)
+
+// insecureJWTSecrets are the placeholders shipped with the code and in
+// .env.example.
+var insecureJWTSecrets = map[string]bool{
+	defaultJWTSecret: true,
+	"your-super-secret-jwt-key-change-in-production":   true,
+	"your-production-jwt-secret-key-at-least-256-bits": true,
+}
+
+// Validate checks settings that only make sense together. In production it
+// also refuses the insecure development defaults.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if port, err := strconv.Atoi(c.Port); err != nil || port < 1 || port > 65535 {
+		errs = append(errs, fmt.Errorf("PORT: %q is not a valid port", c.Port))
+	}
+
+	for _, proxy := range c.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			errs = append(errs, fmt.Errorf("TRUSTED_PROXIES: %q is not an IP address or CIDR range", proxy))
+		}
+	}
+
+	if c.LogFormat != "json" && c.LogFormat != "text" {
+		errs = append(errs, fmt.Errorf("LOG_FORMAT: %q is not json or text", c.LogFormat))
+	}
+
+	switch c.StorageDriver {
+	case "mongo":
+		if _, err := connstring.ParseAndValidate(c.MongoURI); err != nil {
+			errs = append(errs, fmt.Errorf("MONGO_URI: %w", err))
+		}
+	case "sqlite", "memory":
+	default:
+		errs = append(errs, fmt.Errorf("STORAGE_DRIVER: unknown driver %q (want mongo, sqlite or memory)", c.StorageDriver))
+	}
+
+	if c.MongoMaxPoolSize > 0 && c.MongoMinPoolSize > c.MongoMaxPoolSize {
+		errs = append(errs, errors.New("MONGO_MIN_POOL_SIZE must not exceed MONGO_MAX_POOL_SIZE"))
+	}
+	if c.MongoReadPreference != "" {
+		if _, err := readpref.ModeFromString(c.MongoReadPreference); err != nil {
+			errs = append(errs, fmt.Errorf("MONGO_READ_PREFERENCE: %q is not one of primary, primaryPreferred, secondary, secondaryPreferred or nearest", c.MongoReadPreference))
+		}
+	}
+	if n, err := strconv.Atoi(c.MongoWriteConcern); err == nil && n < 0 {
+		errs = append(errs, fmt.Errorf("MONGO_WRITE_CONCERN: %d is not a valid node count", n))
+	}
+	if (c.MongoTLSCertFile == "") != (c.MongoTLSKeyFile == "") {
+		errs = append(errs, errors.New("MONGO_TLS_CERT_FILE and MONGO_TLS_KEY_FILE must be set together"))
+	}
+	for _, compressor := range c.MongoCompressors {
+		if compressor != "zstd" && compressor != "snappy" && compressor != "zlib" {
+			errs = append(errs, fmt.Errorf("MONGO_COMPRESSORS: unknown compressor %q (want zstd, snappy or zlib)", compressor))
+		}
+	}
+
+	if c.MongoReconnectMaxInterval < c.MongoReconnectInterval {
+		errs = append(errs, errors.New("MONGO_RECONNECT_MAX_INTERVAL must not be shorter than MONGO_RECONNECT_INTERVAL"))
+	}
+
+	if u, err := url.Parse(c.TMDBBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, fmt.Errorf("TMDB_BASE_URL: %q is not an http(s) URL", c.TMDBBaseURL))
+	}
+
+	switch c.TracesExporter {
+	case "otlp", "stdout", "none":
+	default:
+		errs = append(errs, fmt.Errorf("OTEL_TRACES_EXPORTER: unknown exporter %q (want otlp, stdout or none)", c.TracesExporter))
+	}
+	if c.OTLPEndpoint != "" {
+		if u, err := url.Parse(c.OTLPEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("OTEL_EXPORTER_OTLP_ENDPOINT: %q is not an http(s) URL", c.OTLPEndpoint))
+		}
+	}
+	if c.TraceSampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("OTEL_TRACES_SAMPLE_RATIO must be at most 1, got %g", c.TraceSampleRatio))
+	}
+
+	if c.Environment == "production" {
+		if c.TMDBAPIKey == "" || c.TMDBAPIKey == defaultTMDBAPIKey {
+			errs = append(errs, errors.New("TMDB_API_KEY must be set to your own key in production"))
+		}
+		if insecureJWTSecrets[c.JWTSecret] || len(c.JWTSecret) < minJWTSecretLength {
+			errs = append(errs, fmt.Errorf("JWT_SECRET must be set to a random value of at least %d characters in production", minJWTSecretLength))
+		}
+		if c.StorageDriver == "memory" {
+			errs = append(errs, errors.New("STORAGE_DRIVER=memory persists nothing and is not allowed in production"))
+		}
+	}
+
+	if err := c.Runtime().validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// databaseFromURI returns the database named in the path of a MongoDB
+// connection string, or defaultDatabase when there is none.
+func databaseFromURI(uri string) string {
+	cs, err := connstring.Parse(uri)
+	if err != nil || cs.Database == "" {
+		return defaultDatabase
+	}
+	return cs.Database
+}
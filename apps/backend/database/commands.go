@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"backend/internal/metrics"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("backend/database")
+
+// commandMonitor records the latency of every command the driver sends and
+// traces it as a client span of the operation that issued it. Command
+// documents are left out of spans as they may hold user data.
+func commandMonitor() *event.CommandMonitor {
+	var spans sync.Map // request ID -> trace.Span
+
+	finish := func(requestID int64, failure string) {
+		value, ok := spans.LoadAndDelete(requestID)
+		if !ok {
+			return
+		}
+		span := value.(trace.Span)
+		if failure != "" {
+			span.RecordError(errors.New(failure))
+			span.SetStatus(codes.Error, failure)
+		}
+		span.End()
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			name := evt.CommandName
+			attrs := []attribute.KeyValue{
+				attribute.String("db.system", "mongodb"),
+				attribute.String("db.name", evt.DatabaseName),
+				attribute.String("db.operation", evt.CommandName),
+			}
+			if collection, ok := evt.Command.Index(0).Value().StringValueOK(); ok {
+				name += " " + collection
+				attrs = append(attrs, attribute.String("db.mongodb.collection", collection))
+			}
+			_, span := tracer.Start(ctx, name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+			)
+			spans.Store(evt.RequestID, span)
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			metrics.MongoOperationDuration.WithLabelValues(evt.CommandName, "success").Observe(evt.Duration.Seconds())
+			finish(evt.RequestID, "")
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			metrics.MongoOperationDuration.WithLabelValues(evt.CommandName, "failure").Observe(evt.Duration.Seconds())
+			finish(evt.RequestID, evt.Failure)
+		},
+	}
+}
@@ -2,47 +2,81 @@ package database
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"time"
 
+	"backend/config"
+
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
-var Client *mongo.Client
-var Database *mongo.Database
+// disconnectTimeout bounds how long Close waits for in-flight operations.
+const disconnectTimeout = 10 * time.Second
 
-func Connect(uri string) (*mongo.Database, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// Manager owns the MongoDB client and the application database. Build one
+// with Open or Connect and hand it to whatever needs the database.
+type Manager struct {
+	client *mongo.Client
+	db     *mongo.Database
+}
 
-	clientOptions := options.Client().ApplyURI(uri)
-	client, err := mongo.Connect(ctx, clientOptions)
+// Open creates the client without waiting for the server, so it succeeds
+// while MongoDB is down; operations block until it becomes reachable. Use a
+// Monitor to find out when that is.
+func Open(cfg *config.Config) (*Manager, error) {
+	opts, err := ClientOptions(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	// Ping the database
-	err = client.Ping(ctx, nil)
+	client, err := mongo.Connect(context.Background(), opts)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Println("Connected to MongoDB!")
+	return &Manager{
+		client: client,
+		db:     client.Database(cfg.MongoDatabase),
+	}, nil
+}
 
-	Client = client
-	Database = client.Database("monorepo")
+// Connect opens the client and waits for the server to answer a ping.
+func Connect(ctx context.Context, cfg *config.Config) (*Manager, error) {
+	m, err := Open(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	return Database, nil
+	if err := m.Ping(ctx); err != nil {
+		m.Close()
+		return nil, err
+	}
+
+	slog.Info("Connected to MongoDB", "database", cfg.MongoDatabase)
+	return m, nil
+}
+
+func (m *Manager) Client() *mongo.Client {
+	return m.client
+}
+
+func (m *Manager) Database() *mongo.Database {
+	return m.db
+}
+
+// Ping checks that the primary answers.
+func (m *Manager) Ping(ctx context.Context) error {
+	return m.client.Ping(ctx, readpref.Primary())
 }
 
-func Disconnect() {
-	if Client != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+// Close disconnects the client, waiting a bounded time for in-flight
+// operations.
+func (m *Manager) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), disconnectTimeout)
+	defer cancel()
 
-		if err := Client.Disconnect(ctx); err != nil {
-			log.Printf("Error disconnecting from MongoDB: %v", err)
-		}
+	if err := m.client.Disconnect(ctx); err != nil {
+		slog.Error("Error disconnecting from MongoDB", "error", err)
 	}
 }
@@ -0,0 +1,147 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrUnreachable wraps the ping error when MongoDB can't be reached.
+var ErrUnreachable = errors.New("mongodb unreachable")
+
+type MonitorConfig struct {
+	// Interval is the first pause between reconnect attempts and the pause
+	// between health checks once connected.
+	Interval time.Duration
+	// MaxInterval caps the exponential backoff between reconnect attempts.
+	MaxInterval time.Duration
+	// OnConnect runs once, the first time MongoDB is reached, before the
+	// monitor reports it available. A failing OnConnect is retried on the
+	// next attempt.
+	OnConnect func(ctx context.Context) error
+}
+
+// MonitorStatus is a snapshot of what the monitor knows about MongoDB.
+type MonitorStatus struct {
+	Available   bool       `json:"available"`
+	Attempts    int        `json:"attempts"`
+	ConnectedAt *time.Time `json:"connectedAt,omitempty"`
+	LastError   string     `json:"lastError,omitempty"`
+}
+
+// Monitor tracks whether MongoDB is reachable. Until it has been reached the
+// API runs in degraded mode; Run keeps retrying with backoff and upgrades to
+// full mode once a ping succeeds.
+type Monitor struct {
+	conn      *Manager
+	cfg       MonitorConfig
+	available atomic.Bool
+
+	mu        sync.RWMutex
+	connected bool
+	status    MonitorStatus
+}
+
+func NewMonitor(conn *Manager, cfg MonitorConfig) *Monitor {
+	return &Monitor{conn: conn, cfg: cfg}
+}
+
+// Available reports whether MongoDB answered the last check.
+func (m *Monitor) Available() bool {
+	return m.available.Load()
+}
+
+// Status returns a copy of the current status.
+func (m *Monitor) Status() MonitorStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.status
+}
+
+// Check pings MongoDB once, running OnConnect the first time it answers.
+// Ping failures are wrapped in ErrUnreachable.
+func (m *Monitor) Check(ctx context.Context) error {
+	err := m.check(ctx)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status.Attempts++
+	m.status.Available = err == nil
+	if err != nil {
+		m.status.LastError = err.Error()
+	} else {
+		m.status.LastError = ""
+	}
+	m.available.Store(err == nil)
+	return err
+}
+
+func (m *Monitor) check(ctx context.Context) error {
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	err := m.conn.Ping(pingCtx)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+
+	m.mu.RLock()
+	connected := m.connected
+	m.mu.RUnlock()
+	if connected {
+		return nil
+	}
+
+	if m.cfg.OnConnect != nil {
+		if err := m.cfg.OnConnect(ctx); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	m.mu.Lock()
+	m.connected = true
+	m.status.ConnectedAt = &now
+	m.mu.Unlock()
+	return nil
+}
+
+// Run checks MongoDB until ctx is cancelled, backing off while it is down.
+func (m *Monitor) Run(ctx context.Context) {
+	wait := m.cfg.Interval
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		wasAvailable := m.Available()
+		err := m.Check(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		switch {
+		case err == nil && !wasAvailable:
+			slog.Info("MongoDB is reachable, leaving degraded mode")
+		case err != nil && wasAvailable:
+			slog.Error("Lost MongoDB connection, entering degraded mode", "error", err)
+		case err != nil && !errors.Is(err, ErrUnreachable):
+			slog.Error("MongoDB reachable but not ready", "error", err)
+		}
+
+		if err == nil {
+			wait = m.cfg.Interval
+			continue
+		}
+		if wasAvailable {
+			wait = m.cfg.Interval
+		} else {
+			wait = min(wait*2, m.cfg.MaxInterval)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package database
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strconv"
+
+	"backend/config"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// ClientOptions builds the driver options from cfg. Settings left at their
+// zero value, and booleans not set anywhere, keep what MONGO_URI says or the
+// driver default.
+func ClientOptions(cfg *config.Config) (*options.ClientOptions, error) {
+	opts := options.Client().ApplyURI(cfg.MongoURI).SetMonitor(commandMonitor())
+
+	if cfg.MongoMaxPoolSize > 0 {
+		opts.SetMaxPoolSize(uint64(cfg.MongoMaxPoolSize))
+	}
+	if cfg.MongoMinPoolSize > 0 {
+		opts.SetMinPoolSize(uint64(cfg.MongoMinPoolSize))
+	}
+	if cfg.MongoMaxConnIdleTime > 0 {
+		opts.SetMaxConnIdleTime(cfg.MongoMaxConnIdleTime)
+	}
+	if cfg.MongoConnectTimeout > 0 {
+		opts.SetConnectTimeout(cfg.MongoConnectTimeout)
+	}
+	if cfg.MongoServerSelectionTimeout > 0 {
+		opts.SetServerSelectionTimeout(cfg.MongoServerSelectionTimeout)
+	}
+	if cfg.MongoSocketTimeout > 0 {
+		opts.SetSocketTimeout(cfg.MongoSocketTimeout)
+	}
+
+	if cfg.MongoReadPreference != "" {
+		mode, err := readpref.ModeFromString(cfg.MongoReadPreference)
+		if err != nil {
+			return nil, err
+		}
+		rp, err := readpref.New(mode)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetReadPreference(rp)
+	}
+
+	if wc := writeConcern(cfg, opts.WriteConcern); wc != nil {
+		opts.SetWriteConcern(wc)
+	}
+
+	if cfg.Source("MONGO_RETRY_WRITES") != "default" {
+		opts.SetRetryWrites(cfg.MongoRetryWrites)
+	}
+	if cfg.Source("MONGO_RETRY_READS") != "default" {
+		opts.SetRetryReads(cfg.MongoRetryReads)
+	}
+
+	if cfg.MongoTLSCAFile != "" || cfg.MongoTLSCertFile != "" {
+		tlsConfig, err := tlsConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	if len(cfg.MongoCompressors) > 0 {
+		opts.SetCompressors(cfg.MongoCompressors)
+	}
+
+	return opts, opts.Validate()
+}
+
+// writeConcern returns the write concern from MONGO_URI, base, with the
+// parts MONGO_WRITE_CONCERN, MONGO_WRITE_JOURNAL and MONGO_WRITE_TIMEOUT set
+// replaced, or nil when none of them is set. The concern is "majority", a
+// node count or a tag set name.
+func writeConcern(cfg *config.Config, base *writeconcern.WriteConcern) *writeconcern.WriteConcern {
+	journalSet := cfg.Source("MONGO_WRITE_JOURNAL") != "default"
+	if cfg.MongoWriteConcern == "" && !journalSet && cfg.MongoWriteTimeout <= 0 {
+		return nil
+	}
+
+	wc := &writeconcern.WriteConcern{}
+	if base != nil {
+		*wc = *base
+	}
+	if n, err := strconv.Atoi(cfg.MongoWriteConcern); err == nil {
+		wc.W = n
+	} else if cfg.MongoWriteConcern != "" {
+		wc.W = cfg.MongoWriteConcern
+	}
+	if journalSet {
+		journal := cfg.MongoWriteJournal
+		wc.Journal = &journal
+	}
+	if cfg.MongoWriteTimeout > 0 {
+		wc.WTimeout = cfg.MongoWriteTimeout
+	}
+	return wc
+}
+
+// tlsConfig trusts the CA in MONGO_TLS_CA_FILE, if any, and presents the
+// client certificate in MONGO_TLS_CERT_FILE and MONGO_TLS_KEY_FILE, if any.
+func tlsConfig(cfg *config.Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cfg.MongoTLSCAFile != "" {
+		pem, err := os.ReadFile(cfg.MongoTLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading MongoDB CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in MongoDB CA file %s", cfg.MongoTLSCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.MongoTLSCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.MongoTLSCertFile, cfg.MongoTLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading MongoDB client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -1,325 +0,0 @@
-package handlers
-
-import (
-	"context"
-	"net/http"
-	"strconv"
-	"time"
-
-	"backend/models"
-
-	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
-)
-
-type UserHandler struct {
-	collection *mongo.Collection
-	validator  *validator.Validate
-}
-
-func NewUserHandler(db *mongo.Database) *UserHandler {
-	return &UserHandler{
-		collection: db.Collection("users"),
-		validator:  validator.New(),
-	}
-}
-
-func (h *UserHandler) GetUsers(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	// Parse query parameters
-	limitStr := c.DefaultQuery("limit", "10")
-	offsetStr := c.DefaultQuery("offset", "0")
-
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 || limit > 100 {
-		limit = 10
-	}
-
-	offset, err := strconv.Atoi(offsetStr)
-	if err != nil || offset < 0 {
-		offset = 0
-	}
-
-	// Count total documents
-	total, err := h.collection.CountDocuments(ctx, bson.M{})
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "database_error",
-			Message: "Failed to count users",
-		})
-		return
-	}
-
-	// Find users with pagination
-	opts := options.Find().SetLimit(int64(limit)).SetSkip(int64(offset))
-	cursor, err := h.collection.Find(ctx, bson.M{}, opts)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "database_error",
-			Message: "Failed to fetch users",
-		})
-		return
-	}
-	defer cursor.Close(ctx)
-
-	var users []models.User
-	if err = cursor.All(ctx, &users); err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "database_error",
-			Message: "Failed to decode users",
-		})
-		return
-	}
-
-	if users == nil {
-		users = []models.User{}
-	}
-
-	response := models.UsersResponse{
-		Users:  users,
-		Total:  total,
-		Limit:  limit,
-		Offset: offset,
-	}
-
-	c.JSON(http.StatusOK, response)
-}
-
-func (h *UserHandler) GetUserByID(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	id := c.Param("id")
-	objectID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_id",
-			Message: "Invalid user ID format",
-		})
-		return
-	}
-
-	var user models.User
-	err = h.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&user)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "user_not_found",
-				Message: "User not found",
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "database_error",
-			Message: "Failed to fetch user",
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, user)
-}
-
-func (h *UserHandler) CreateUser(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	var req models.CreateUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-			Details: err.Error(),
-		})
-		return
-	}
-
-	if err := h.validator.Struct(req); err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "validation_error",
-			Message: "Validation failed",
-			Details: err.Error(),
-		})
-		return
-	}
-
-	// Check if email already exists
-	count, err := h.collection.CountDocuments(ctx, bson.M{"email": req.Email})
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "database_error",
-			Message: "Failed to check email uniqueness",
-		})
-		return
-	}
-	if count > 0 {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "email_exists",
-			Message: "Email already exists",
-		})
-		return
-	}
-
-	user := models.User{
-		ID:        primitive.NewObjectID(),
-		Email:     req.Email,
-		FirstName: req.FirstName,
-		LastName:  req.LastName,
-		Age:       req.Age,
-		IsActive:  true,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-
-	_, err = h.collection.InsertOne(ctx, user)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "database_error",
-			Message: "Failed to create user",
-		})
-		return
-	}
-
-	c.JSON(http.StatusCreated, user)
-}
-
-func (h *UserHandler) UpdateUser(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	id := c.Param("id")
-	objectID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_id",
-			Message: "Invalid user ID format",
-		})
-		return
-	}
-
-	var req models.UpdateUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-			Details: err.Error(),
-		})
-		return
-	}
-
-	if err := h.validator.Struct(req); err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "validation_error",
-			Message: "Validation failed",
-			Details: err.Error(),
-		})
-		return
-	}
-
-	// Build update document
-	update := bson.M{"$set": bson.M{"updatedAt": time.Now()}}
-	if req.Email != nil {
-		// Check if email already exists (excluding current user)
-		count, err := h.collection.CountDocuments(ctx, bson.M{
-			"email": *req.Email,
-			"_id":   bson.M{"$ne": objectID},
-		})
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-				Error:   "database_error",
-				Message: "Failed to check email uniqueness",
-			})
-			return
-		}
-		if count > 0 {
-			c.JSON(http.StatusBadRequest, models.ErrorResponse{
-				Error:   "email_exists",
-				Message: "Email already exists",
-			})
-			return
-		}
-		update["$set"].(bson.M)["email"] = *req.Email
-	}
-	if req.FirstName != nil {
-		update["$set"].(bson.M)["firstName"] = *req.FirstName
-	}
-	if req.LastName != nil {
-		update["$set"].(bson.M)["lastName"] = *req.LastName
-	}
-	if req.Age != nil {
-		update["$set"].(bson.M)["age"] = *req.Age
-	}
-	if req.IsActive != nil {
-		update["$set"].(bson.M)["isActive"] = *req.IsActive
-	}
-
-	// Update the user
-	result, err := h.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "database_error",
-			Message: "Failed to update user",
-		})
-		return
-	}
-
-	if result.MatchedCount == 0 {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "user_not_found",
-			Message: "User not found",
-		})
-		return
-	}
-
-	// Fetch and return updated user
-	var user models.User
-	err = h.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&user)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "database_error",
-			Message: "Failed to fetch updated user",
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, user)
-}
-
-func (h *UserHandler) DeleteUser(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	id := c.Param("id")
-	objectID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_id",
-			Message: "Invalid user ID format",
-		})
-		return
-	}
-
-	result, err := h.collection.DeleteOne(ctx, bson.M{"_id": objectID})
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "database_error",
-			Message: "Failed to delete user",
-		})
-		return
-	}
-
-	if result.DeletedCount == 0 {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "user_not_found",
-			Message: "User not found",
-		})
-		return
-	}
-
-	c.Status(http.StatusNoContent)
-}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"net/http"
+
+	"backend/internal/worker"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	refresher   *worker.CatalogRefresher
+	changesSync *worker.ChangesSync
+	purger      *worker.Purger
+}
+
+func NewAdminHandler(refresher *worker.CatalogRefresher, changesSync *worker.ChangesSync, purger *worker.Purger) *AdminHandler {
+	return &AdminHandler{
+		refresher:   refresher,
+		changesSync: changesSync,
+		purger:      purger,
+	}
+}
+
+// GetRefreshStatus godoc
+// @Summary Get catalog refresh progress
+// @Description Report what the background catalog refresh worker is doing
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} worker.RefreshProgress
+// @Failure 401 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/refresh [get]
+func (h *AdminHandler) GetRefreshStatus(c *gin.Context) {
+	if h.refresher == nil {
+		RespondError(c, http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "refresh_disabled",
+			Message: "Catalog refresh worker is not running",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.refresher.Progress())
+}
+
+// GetSyncStatus godoc
+// @Summary Get TMDB changes sync progress
+// @Description Report checkpoints and counters of the TMDB changes sync job
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} worker.SyncProgress
+// @Failure 401 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/sync [get]
+func (h *AdminHandler) GetSyncStatus(c *gin.Context) {
+	if h.changesSync == nil {
+		RespondError(c, http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "sync_disabled",
+			Message: "TMDB changes sync is not running",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.changesSync.Progress())
+}
+
+// TriggerSync godoc
+// @Summary Trigger a TMDB changes sync
+// @Description Start a TMDB changes sync run without waiting for the next interval
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 202 {object} worker.SyncProgress
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/sync [post]
+func (h *AdminHandler) TriggerSync(c *gin.Context) {
+	if h.changesSync == nil {
+		RespondError(c, http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "sync_disabled",
+			Message: "TMDB changes sync is not running",
+		})
+		return
+	}
+
+	if !h.changesSync.Trigger() {
+		RespondError(c, http.StatusConflict, ErrorResponse{
+			Error:   "sync_pending",
+			Message: "A sync run is already pending",
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, h.changesSync.Progress())
+}
+
+// GetPurgeStatus godoc
+// @Summary Get purge job progress
+// @Description Report how many soft-deleted records the purge job has removed
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} worker.PurgeProgress
+// @Failure 401 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/purge [get]
+func (h *AdminHandler) GetPurgeStatus(c *gin.Context) {
+	if h.purger == nil {
+		RespondError(c, http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "purge_disabled",
+			Message: "Purge job is not running",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.purger.Progress())
+}
@@ -0,0 +1,392 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/internal/domain"
+	"backend/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CatalogAdminHandler struct {
+	catalogAdminUseCase *usecase.CatalogAdminUseCase
+}
+
+func NewCatalogAdminHandler(catalogAdminUseCase *usecase.CatalogAdminUseCase) *CatalogAdminHandler {
+	return &CatalogAdminHandler{
+		catalogAdminUseCase: catalogAdminUseCase,
+	}
+}
+
+// GetMovie godoc
+// @Summary Get a stored movie with its override
+// @Description Get a stored movie, including hidden ones, with its curated override
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "Movie ID"
+// @Success 200 {object} AdminMovieResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/movies/{id} [get]
+func (h *CatalogAdminHandler) GetMovie(c *gin.Context) {
+	movie, override, err := h.catalogAdminUseCase.GetMovie(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondCatalogAdminError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(movie.Version))
+	c.JSON(http.StatusOK, AdminMovieResponse{Movie: movie, Override: override})
+}
+
+// EditMovie godoc
+// @Summary Edit movie metadata
+// @Description Store curated values for a movie that take precedence over TMDB data
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "Movie ID"
+// @Param If-Match header string true "ETags of the versions the edit is based on, or *"
+// @Param fields body domain.OverrideFields true "Fields to override; null drops a curated value"
+// @Success 200 {object} AdminMovieResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
+// @Router /admin/movies/{id} [patch]
+func (h *CatalogAdminHandler) EditMovie(c *gin.Context) {
+	expected, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var patch domain.OverridePatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	movie, override, err := h.catalogAdminUseCase.EditMovie(c.Request.Context(), c.Param("id"), expected, patch)
+	if err != nil {
+		respondCatalogAdminError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(movie.Version))
+	c.JSON(http.StatusOK, AdminMovieResponse{Movie: movie, Override: override})
+}
+
+// ResetMovie godoc
+// @Summary Drop a movie's curated override
+// @Description Remove the override of a movie and restore its TMDB data
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "Movie ID"
+// @Success 200 {object} AdminMovieResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/movies/{id}/override [delete]
+func (h *CatalogAdminHandler) ResetMovie(c *gin.Context) {
+	movie, err := h.catalogAdminUseCase.ResetMovie(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondCatalogAdminError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(movie.Version))
+	c.JSON(http.StatusOK, AdminMovieResponse{Movie: movie})
+}
+
+// HideMovie godoc
+// @Summary Hide a movie
+// @Description Hide a movie from every public endpoint
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "Movie ID"
+// @Success 200 {object} AdminMovieResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/movies/{id}/hide [post]
+func (h *CatalogAdminHandler) HideMovie(c *gin.Context) {
+	h.setMovieHidden(c, true)
+}
+
+// UnhideMovie godoc
+// @Summary Unhide a movie
+// @Description Show a hidden movie on public endpoints again
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "Movie ID"
+// @Success 200 {object} AdminMovieResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/movies/{id}/unhide [post]
+func (h *CatalogAdminHandler) UnhideMovie(c *gin.Context) {
+	h.setMovieHidden(c, false)
+}
+
+func (h *CatalogAdminHandler) setMovieHidden(c *gin.Context, hidden bool) {
+	movie, err := h.catalogAdminUseCase.SetMovieHidden(c.Request.Context(), c.Param("id"), hidden)
+	if err != nil {
+		respondCatalogAdminError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(movie.Version))
+	c.JSON(http.StatusOK, AdminMovieResponse{Movie: movie})
+}
+
+// DeleteMovie godoc
+// @Summary Delete a movie
+// @Description Soft-delete a movie; it can be restored until the purge job removes it
+// @Tags admin
+// @Security AdminToken
+// @Param id path string true "Movie ID"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/movies/{id} [delete]
+func (h *CatalogAdminHandler) DeleteMovie(c *gin.Context) {
+	if err := h.catalogAdminUseCase.DeleteMovie(c.Request.Context(), c.Param("id")); err != nil {
+		respondCatalogAdminError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RestoreMovie godoc
+// @Summary Restore a deleted movie
+// @Description Bring back a soft-deleted movie
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "Movie ID"
+// @Success 200 {object} AdminMovieResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/movies/{id}/restore [post]
+func (h *CatalogAdminHandler) RestoreMovie(c *gin.Context) {
+	movie, err := h.catalogAdminUseCase.RestoreMovie(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondCatalogAdminError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(movie.Version))
+	c.JSON(http.StatusOK, AdminMovieResponse{Movie: movie})
+}
+
+// GetTVShow godoc
+// @Summary Get a stored TV show with its override
+// @Description Get a stored TV show, including hidden ones, with its curated override
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "TV show ID"
+// @Success 200 {object} AdminTVShowResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/tv/{id} [get]
+func (h *CatalogAdminHandler) GetTVShow(c *gin.Context) {
+	tvShow, override, err := h.catalogAdminUseCase.GetTVShow(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondCatalogAdminError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(tvShow.Version))
+	c.JSON(http.StatusOK, AdminTVShowResponse{TVShow: tvShow, Override: override})
+}
+
+// EditTVShow godoc
+// @Summary Edit TV show metadata
+// @Description Store curated values for a TV show that take precedence over TMDB data
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "TV show ID"
+// @Param If-Match header string true "ETags of the versions the edit is based on, or *"
+// @Param fields body domain.OverrideFields true "Fields to override; null drops a curated value"
+// @Success 200 {object} AdminTVShowResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
+// @Router /admin/tv/{id} [patch]
+func (h *CatalogAdminHandler) EditTVShow(c *gin.Context) {
+	expected, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var patch domain.OverridePatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	tvShow, override, err := h.catalogAdminUseCase.EditTVShow(c.Request.Context(), c.Param("id"), expected, patch)
+	if err != nil {
+		respondCatalogAdminError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(tvShow.Version))
+	c.JSON(http.StatusOK, AdminTVShowResponse{TVShow: tvShow, Override: override})
+}
+
+// ResetTVShow godoc
+// @Summary Drop a TV show's curated override
+// @Description Remove the override of a TV show and restore its TMDB data
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "TV show ID"
+// @Success 200 {object} AdminTVShowResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/tv/{id}/override [delete]
+func (h *CatalogAdminHandler) ResetTVShow(c *gin.Context) {
+	tvShow, err := h.catalogAdminUseCase.ResetTVShow(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondCatalogAdminError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(tvShow.Version))
+	c.JSON(http.StatusOK, AdminTVShowResponse{TVShow: tvShow})
+}
+
+// HideTVShow godoc
+// @Summary Hide a TV show
+// @Description Hide a TV show from every public endpoint
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "TV show ID"
+// @Success 200 {object} AdminTVShowResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/tv/{id}/hide [post]
+func (h *CatalogAdminHandler) HideTVShow(c *gin.Context) {
+	h.setTVShowHidden(c, true)
+}
+
+// UnhideTVShow godoc
+// @Summary Unhide a TV show
+// @Description Show a hidden TV show on public endpoints again
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "TV show ID"
+// @Success 200 {object} AdminTVShowResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/tv/{id}/unhide [post]
+func (h *CatalogAdminHandler) UnhideTVShow(c *gin.Context) {
+	h.setTVShowHidden(c, false)
+}
+
+func (h *CatalogAdminHandler) setTVShowHidden(c *gin.Context, hidden bool) {
+	tvShow, err := h.catalogAdminUseCase.SetTVShowHidden(c.Request.Context(), c.Param("id"), hidden)
+	if err != nil {
+		respondCatalogAdminError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(tvShow.Version))
+	c.JSON(http.StatusOK, AdminTVShowResponse{TVShow: tvShow})
+}
+
+// DeleteTVShow godoc
+// @Summary Delete a TV show
+// @Description Soft-delete a TV show; it can be restored until the purge job removes it
+// @Tags admin
+// @Security AdminToken
+// @Param id path string true "TV show ID"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/tv/{id} [delete]
+func (h *CatalogAdminHandler) DeleteTVShow(c *gin.Context) {
+	if err := h.catalogAdminUseCase.DeleteTVShow(c.Request.Context(), c.Param("id")); err != nil {
+		respondCatalogAdminError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RestoreTVShow godoc
+// @Summary Restore a deleted TV show
+// @Description Bring back a soft-deleted TV show
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "TV show ID"
+// @Success 200 {object} AdminTVShowResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/tv/{id}/restore [post]
+func (h *CatalogAdminHandler) RestoreTVShow(c *gin.Context) {
+	tvShow, err := h.catalogAdminUseCase.RestoreTVShow(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondCatalogAdminError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(tvShow.Version))
+	c.JSON(http.StatusOK, AdminTVShowResponse{TVShow: tvShow})
+}
+
+// respondCatalogAdminError maps catalog admin use case errors to responses.
+// Anything unexpected is reported as a failed upstream or storage call.
+func respondCatalogAdminError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrMovieNotFound):
+		RespondError(c, http.StatusNotFound, ErrorResponse{
+			Error:   "movie_not_found",
+			Message: "Movie not found",
+		})
+	case errors.Is(err, usecase.ErrTVShowNotFound):
+		RespondError(c, http.StatusNotFound, ErrorResponse{
+			Error:   "tv_show_not_found",
+			Message: "TV show not found",
+		})
+	case errors.Is(err, domain.ErrVersionConflict):
+		RespondError(c, http.StatusPreconditionFailed, ErrorResponse{
+			Error:   "version_conflict",
+			Message: "The entry was changed since it was read; fetch it again and retry",
+		})
+	case errors.Is(err, usecase.ErrInvalidInput):
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "At least one field must be overridden",
+		})
+	default:
+		c.Error(err)
+		RespondError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "update_failed",
+			Message: "Failed to update catalog entry",
+		})
+	}
+}
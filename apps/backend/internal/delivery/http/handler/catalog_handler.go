@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"backend/internal/domain"
+	"backend/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CatalogHandler serves the movies and TV shows stored in our own database,
+// as opposed to the TMDB-backed endpoints.
+type CatalogHandler struct {
+	movieUseCase  *usecase.MovieUseCase
+	tvShowUseCase *usecase.TVShowUseCase
+}
+
+func NewCatalogHandler(movieUseCase *usecase.MovieUseCase, tvShowUseCase *usecase.TVShowUseCase) *CatalogHandler {
+	return &CatalogHandler{
+		movieUseCase:  movieUseCase,
+		tvShowUseCase: tvShowUseCase,
+	}
+}
+
+// ListMovies godoc
+// @Summary Browse the local movie catalog
+// @Description List stored movies, optionally filtered by genre
+// @Tags catalog
+// @Accept json
+// @Produce json
+// @Param genre query int false "Genre ID"
+// @Param sort query string false "Sort order" Enums(vote, release_date, recent) default(vote)
+// @Param limit query int false "Page size" default(20)
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param includeTotal query bool false "Count all matching movies" default(false)
+// @Success 200 {object} CatalogMoviesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /catalog/movies [get]
+func (h *CatalogHandler) ListMovies(c *gin.Context) {
+	query, ok := catalogQuery(c)
+	if !ok {
+		return
+	}
+
+	page := pageRequest(c)
+	result, err := h.movieUseCase.BrowseCatalog(c.Request.Context(), query, page)
+	if err == domain.ErrInvalidCursor {
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_cursor",
+			Message: "Invalid or expired page cursor",
+		})
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		RespondError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "fetch_error",
+			Message: "Failed to list movies",
+		})
+		return
+	}
+
+	movies := result.Items
+	if movies == nil {
+		movies = []*domain.Movie{}
+	}
+	c.JSON(http.StatusOK, CatalogMoviesResponse{
+		Movies:     movies,
+		NextCursor: result.NextCursor,
+		Total:      result.Total,
+		Limit:      page.Limit,
+	})
+}
+
+// ListTVShows godoc
+// @Summary Browse the local TV show catalog
+// @Description List stored TV shows, optionally filtered by genre
+// @Tags catalog
+// @Accept json
+// @Produce json
+// @Param genre query int false "Genre ID"
+// @Param sort query string false "Sort order" Enums(vote, release_date, recent) default(vote)
+// @Param limit query int false "Page size" default(20)
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param includeTotal query bool false "Count all matching TV shows" default(false)
+// @Success 200 {object} CatalogTVShowsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /catalog/tv [get]
+func (h *CatalogHandler) ListTVShows(c *gin.Context) {
+	query, ok := catalogQuery(c)
+	if !ok {
+		return
+	}
+
+	page := pageRequest(c)
+	result, err := h.tvShowUseCase.BrowseCatalog(c.Request.Context(), query, page)
+	if err == domain.ErrInvalidCursor {
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_cursor",
+			Message: "Invalid or expired page cursor",
+		})
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		RespondError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "fetch_error",
+			Message: "Failed to list TV shows",
+		})
+		return
+	}
+
+	tvShows := result.Items
+	if tvShows == nil {
+		tvShows = []*domain.TVShow{}
+	}
+	c.JSON(http.StatusOK, CatalogTVShowsResponse{
+		TVShows:    tvShows,
+		NextCursor: result.NextCursor,
+		Total:      result.Total,
+		Limit:      page.Limit,
+	})
+}
+
+// SearchMovies godoc
+// @Summary Search the local movie catalog
+// @Description Relevance-ranked search over stored movie titles, taglines and overviews
+// @Tags catalog
+// @Accept json
+// @Produce json
+// @Param query query string true "Search query"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Results to skip" default(0)
+// @Param includeTotal query bool false "Count all matching movies" default(false)
+// @Success 200 {object} CatalogMovieSearchResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /catalog/movies/search [get]
+func (h *CatalogHandler) SearchMovies(c *gin.Context) {
+	query := c.Query("query")
+	if query == "" {
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "missing_query",
+			Message: "Search query is required",
+		})
+		return
+	}
+
+	limit, offset := limitOffset(c)
+	includeTotal, _ := strconv.ParseBool(c.Query("includeTotal"))
+	results, total, err := h.movieUseCase.SearchCatalog(c.Request.Context(), query, limit, offset, includeTotal)
+	if err == usecase.ErrInvalidInput {
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "missing_query",
+			Message: "Search query is required",
+		})
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		RespondError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "search_error",
+			Message: "Failed to search movies",
+		})
+		return
+	}
+
+	if results == nil {
+		results = []*domain.MovieSearchResult{}
+	}
+	c.JSON(http.StatusOK, CatalogMovieSearchResponse{
+		Results: results,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	})
+}
+
+// SearchTVShows godoc
+// @Summary Search the local TV show catalog
+// @Description Relevance-ranked search over stored TV show names and overviews
+// @Tags catalog
+// @Accept json
+// @Produce json
+// @Param query query string true "Search query"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Results to skip" default(0)
+// @Param includeTotal query bool false "Count all matching TV shows" default(false)
+// @Success 200 {object} CatalogTVShowSearchResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /catalog/tv/search [get]
+func (h *CatalogHandler) SearchTVShows(c *gin.Context) {
+	query := c.Query("query")
+	if query == "" {
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "missing_query",
+			Message: "Search query is required",
+		})
+		return
+	}
+
+	limit, offset := limitOffset(c)
+	includeTotal, _ := strconv.ParseBool(c.Query("includeTotal"))
+	results, total, err := h.tvShowUseCase.SearchCatalog(c.Request.Context(), query, limit, offset, includeTotal)
+	if err == usecase.ErrInvalidInput {
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "missing_query",
+			Message: "Search query is required",
+		})
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		RespondError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "search_error",
+			Message: "Failed to search TV shows",
+		})
+		return
+	}
+
+	if results == nil {
+		results = []*domain.TVShowSearchResult{}
+	}
+	c.JSON(http.StatusOK, CatalogTVShowSearchResponse{
+		Results: results,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	})
+}
+
+// catalogQuery parses the genre, sort and paging parameters of a catalog
+// listing. It writes a 400 response and returns false when they're invalid.
+func catalogQuery(c *gin.Context) (domain.CatalogQuery, bool) {
+	query := domain.CatalogQuery{
+		Sort: domain.CatalogSort(c.DefaultQuery("sort", string(domain.SortByVote))),
+	}
+
+	switch query.Sort {
+	case domain.SortByVote, domain.SortByReleaseDate, domain.SortByRecent:
+	default:
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_sort",
+			Message: "Sort must be 'vote', 'release_date' or 'recent'",
+		})
+		return query, false
+	}
+
+	if genre := c.Query("genre"); genre != "" {
+		genreID, err := strconv.Atoi(genre)
+		if err != nil || genreID < 1 {
+			RespondError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_genre_id",
+				Message: "Invalid genre ID format",
+			})
+			return query, false
+		}
+		query.GenreID = genreID
+	}
+
+	return query, true
+}
+
+// pageRequest reads the limit, cursor and includeTotal query parameters of
+// a keyset-paginated listing.
+func pageRequest(c *gin.Context) domain.PageRequest {
+	limit, _ := limitOffset(c)
+	includeTotal, _ := strconv.ParseBool(c.Query("includeTotal"))
+
+	return domain.PageRequest{
+		Cursor:    c.Query("cursor"),
+		Limit:     limit,
+		WithTotal: includeTotal,
+	}
+}
+
+// limitOffset reads limit/offset query parameters, clamping the limit to
+// 1..100 (default 20) and the offset to zero or more.
+func limitOffset(c *gin.Context) (int, int) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	return limit, offset
+}
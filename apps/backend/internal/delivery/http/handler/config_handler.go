@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"backend/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ConfigHandler struct {
+	cfg *config.Config
+}
+
+func NewConfigHandler(cfg *config.Config) *ConfigHandler {
+	return &ConfigHandler{cfg: cfg}
+}
+
+// GetConfigStatus godoc
+// @Summary Get the active configuration version
+// @Description Report the reloadable settings in effect, their version and how the last SIGHUP reload went
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} config.ReloadStatus
+// @Failure 401 {object} ErrorResponse
+// @Router /admin/config [get]
+func (h *ConfigHandler) GetConfigStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, h.cfg.ReloadStatus())
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etag formats a record version as a strong entity tag.
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ifMatchVersion reads the record versions a client based its edit on from
+// the If-Match header: "*" or a comma-separated list of entity tags. Weak
+// tags count too, since proxies that compress responses weaken our tags, and
+// tags that don't name a version never match. It answers 428 when the header
+// is missing and reports whether the handler may go on.
+func ifMatchVersion(c *gin.Context) (domain.VersionCondition, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		RespondError(c, http.StatusPreconditionRequired, ErrorResponse{
+			Error:   "precondition_required",
+			Message: "If-Match header with the current ETag is required",
+		})
+		return domain.VersionCondition{}, false
+	}
+	if header == "*" {
+		return domain.VersionCondition{Any: true}, true
+	}
+
+	var condition domain.VersionCondition
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		if version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64); err == nil {
+			condition.Versions = append(condition.Versions, version)
+		}
+	}
+
+	return condition, true
+}
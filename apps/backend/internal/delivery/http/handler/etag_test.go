@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		header   string
+		any      bool
+		versions []int64
+		matches  int64
+	}{
+		{name: "strong tag", header: `"3"`, versions: []int64{3}, matches: 3},
+		{name: "weak tag", header: `W/"3"`, versions: []int64{3}, matches: 3},
+		{name: "list", header: `"2", W/"5" ,"7"`, versions: []int64{2, 5, 7}, matches: 5},
+		{name: "any", header: "*", any: true, matches: 42},
+		{name: "any with spaces", header: "  *  ", any: true, matches: 1},
+		{name: "unquoted", header: "3", matches: -1},
+		{name: "not a version", header: `"abc", "4"`, versions: []int64{4}, matches: 4},
+		{name: "only junk", header: `"v1"`, matches: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPatch, "/", nil)
+			c.Request.Header.Set("If-Match", tt.header)
+
+			condition, ok := ifMatchVersion(c)
+			if !ok {
+				t.Fatal("ifMatchVersion rejected a present header")
+			}
+			if condition.Any != tt.any || !slices.Equal(condition.Versions, tt.versions) {
+				t.Errorf("condition is %+v, want any %v and versions %v", condition, tt.any, tt.versions)
+			}
+			if tt.matches >= 0 && !condition.Matches(tt.matches) {
+				t.Errorf("condition %+v doesn't match version %d", condition, tt.matches)
+			}
+			if tt.matches < 0 && condition.Matches(3) {
+				t.Errorf("condition %+v matches a version it doesn't name", condition)
+			}
+		})
+	}
+}
+
+func TestIfMatchVersionRequiresHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, header := range []string{"", "   "} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/", nil)
+		if header != "" {
+			c.Request.Header.Set("If-Match", header)
+		}
+
+		if _, ok := ifMatchVersion(c); ok {
+			t.Errorf("If-Match %q was accepted", header)
+		}
+		if w.Code != http.StatusPreconditionRequired {
+			t.Errorf("If-Match %q answered %d, want %d", header, w.Code, http.StatusPreconditionRequired)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"backend/internal/buildinfo"
+	"backend/internal/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	checker   *health.Checker
+	startedAt time.Time
+}
+
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{checker: checker, startedAt: time.Now()}
+}
+
+// Livez godoc
+// @Summary Liveness probe
+// @Description Report that the process is up, with its build. Dependencies are not checked, so a failing database never gets the API restarted.
+// @Tags health
+// @Produce json
+// @Success 200 {object} LivenessResponse
+// @Router /livez [get]
+func (h *HealthHandler) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, LivenessResponse{
+		Status:        health.StatusOK,
+		Build:         buildinfo.Get(),
+		UptimeSeconds: int64(time.Since(h.startedAt).Seconds()),
+	})
+}
+
+// Readyz godoc
+// @Summary Readiness probe
+// @Description Check the database, TMDB (cached) and the background workers. Answers 503 only when neither the database nor TMDB is reachable; with one of them down the API still serves what it can and reports degraded.
+// @Tags health
+// @Produce json
+// @Success 200 {object} ReadinessResponse
+// @Failure 503 {object} ReadinessResponse
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	report := h.checker.Run(c.Request.Context())
+	status := http.StatusOK
+	if report.Status == health.StatusUnavailable {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, ReadinessResponse{
+		Report: report,
+		Build:  buildinfo.Get(),
+	})
+}
@@ -27,6 +27,7 @@ func NewMovieHandler(movieUseCase *usecase.MovieUseCase) *MovieHandler {
 // @Produce json
 // @Param id path string true "Movie ID"
 // @Success 200 {object} domain.Movie
+// @Header 200 {string} ETag "Version of the movie"
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /movies/{id} [get]
@@ -35,13 +36,14 @@ func (h *MovieHandler) GetMovie(c *gin.Context) {
 
 	movie, err := h.movieUseCase.GetMovieByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, ErrorResponse{
+		RespondError(c, http.StatusNotFound, ErrorResponse{
 			Error:   "movie_not_found",
 			Message: "Movie not found",
 		})
 		return
 	}
 
+	c.Header("ETag", etag(movie.Version))
 	c.JSON(http.StatusOK, movie)
 }
 
@@ -53,6 +55,7 @@ func (h *MovieHandler) GetMovie(c *gin.Context) {
 // @Produce json
 // @Param tmdb_id path int true "TMDB Movie ID"
 // @Success 200 {object} domain.Movie
+// @Header 200 {string} ETag "Version of the movie"
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /movies/tmdb/{tmdb_id} [get]
@@ -60,7 +63,7 @@ func (h *MovieHandler) GetMovieByTMDBID(c *gin.Context) {
 	tmdbIDStr := c.Param("tmdb_id")
 	tmdbID, err := strconv.Atoi(tmdbIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_tmdb_id",
 			Message: "Invalid TMDB ID format",
 		})
@@ -69,13 +72,14 @@ func (h *MovieHandler) GetMovieByTMDBID(c *gin.Context) {
 
 	movie, err := h.movieUseCase.GetMovieByTMDBID(c.Request.Context(), tmdbID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, ErrorResponse{
+		RespondError(c, http.StatusNotFound, ErrorResponse{
 			Error:   "movie_not_found",
 			Message: "Movie not found",
 		})
 		return
 	}
 
+	c.Header("ETag", etag(movie.Version))
 	c.JSON(http.StatusOK, movie)
 }
 
@@ -93,7 +97,7 @@ func (h *MovieHandler) GetMovieByTMDBID(c *gin.Context) {
 func (h *MovieHandler) SearchMovies(c *gin.Context) {
 	query := c.Query("query")
 	if query == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "missing_query",
 			Message: "Search query is required",
 		})
@@ -107,7 +111,8 @@ func (h *MovieHandler) SearchMovies(c *gin.Context) {
 
 	movies, totalPages, err := h.movieUseCase.SearchMovies(c.Request.Context(), query, page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		c.Error(err)
+		RespondError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "search_error",
 			Message: "Failed to search movies",
 		})
@@ -139,7 +144,8 @@ func (h *MovieHandler) GetPopularMovies(c *gin.Context) {
 
 	movies, totalPages, err := h.movieUseCase.GetPopularMovies(c.Request.Context(), page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		c.Error(err)
+		RespondError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "fetch_error",
 			Message: "Failed to fetch popular movies",
 		})
@@ -169,7 +175,7 @@ func (h *MovieHandler) GetMoviesByGenre(c *gin.Context) {
 	genreIDStr := c.Param("genre_id")
 	genreID, err := strconv.Atoi(genreIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_genre_id",
 			Message: "Invalid genre ID format",
 		})
@@ -183,7 +189,8 @@ func (h *MovieHandler) GetMoviesByGenre(c *gin.Context) {
 
 	movies, totalPages, err := h.movieUseCase.GetMoviesByGenre(c.Request.Context(), genreID, page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		c.Error(err)
+		RespondError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "fetch_error",
 			Message: "Failed to fetch movies by genre",
 		})
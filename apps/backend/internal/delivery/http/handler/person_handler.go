@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"backend/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PersonHandler struct {
+	personUseCase *usecase.PersonUseCase
+}
+
+func NewPersonHandler(personUseCase *usecase.PersonUseCase) *PersonHandler {
+	return &PersonHandler{
+		personUseCase: personUseCase,
+	}
+}
+
+// GetPersonByTMDBID godoc
+// @Summary Get person by TMDB ID
+// @Description Get person details by TMDB ID (fetches from TMDB if not in database)
+// @Tags people
+// @Accept json
+// @Produce json
+// @Param tmdb_id path int true "TMDB Person ID"
+// @Success 200 {object} domain.Person
+// @Header 200 {string} ETag "Version of the person"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /people/tmdb/{tmdb_id} [get]
+func (h *PersonHandler) GetPersonByTMDBID(c *gin.Context) {
+	tmdbID, err := strconv.Atoi(c.Param("tmdb_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_tmdb_id",
+			Message: "Invalid TMDB ID format",
+		})
+		return
+	}
+
+	person, err := h.personUseCase.GetPersonByTMDBID(c.Request.Context(), tmdbID)
+	if err != nil {
+		RespondError(c, http.StatusNotFound, ErrorResponse{
+			Error:   "person_not_found",
+			Message: "Person not found",
+		})
+		return
+	}
+
+	c.Header("ETag", etag(person.Version))
+	c.JSON(http.StatusOK, person)
+}
@@ -1,12 +1,27 @@
 package handler
 
-import "backend/internal/domain"
+import (
+	"backend/internal/buildinfo"
+	"backend/internal/domain"
+	"backend/internal/health"
+	"backend/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
 
 // Response types for API
 type ErrorResponse struct {
 	Error   string      `json:"error"`
 	Message string      `json:"message"`
 	Details interface{} `json:"details,omitempty"`
+	// RequestID matches the X-Request-ID response header, for support
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// RespondError writes resp with status, tagged with the request's ID.
+func RespondError(c *gin.Context, status int, resp ErrorResponse) {
+	resp.RequestID = requestid.FromContext(c.Request.Context())
+	c.JSON(status, resp)
 }
 
 type PaginatedMoviesResponse struct {
@@ -21,6 +36,34 @@ type PaginatedTVShowsResponse struct {
 	TotalPages int              `json:"totalPages"`
 }
 
+type CatalogMoviesResponse struct {
+	Movies     []*domain.Movie `json:"movies"`
+	NextCursor string          `json:"nextCursor,omitempty"`
+	Total      *int64          `json:"total,omitempty"`
+	Limit      int             `json:"limit"`
+}
+
+type CatalogTVShowsResponse struct {
+	TVShows    []*domain.TVShow `json:"tvShows"`
+	NextCursor string           `json:"nextCursor,omitempty"`
+	Total      *int64           `json:"total,omitempty"`
+	Limit      int              `json:"limit"`
+}
+
+type CatalogMovieSearchResponse struct {
+	Results []*domain.MovieSearchResult `json:"results"`
+	Total   *int64                      `json:"total,omitempty"`
+	Limit   int                         `json:"limit"`
+	Offset  int                         `json:"offset"`
+}
+
+type CatalogTVShowSearchResponse struct {
+	Results []*domain.TVShowSearchResult `json:"results"`
+	Total   *int64                       `json:"total,omitempty"`
+	Limit   int                          `json:"limit"`
+	Offset  int                          `json:"offset"`
+}
+
 type GenresResponse struct {
 	Genres []domain.Genre `json:"genres"`
 }
@@ -32,11 +75,22 @@ type HealthResponse struct {
 	Version   string `json:"version"`
 }
 
+type LivenessResponse struct {
+	Status        string         `json:"status"`
+	Build         buildinfo.Info `json:"build"`
+	UptimeSeconds int64          `json:"uptimeSeconds"`
+}
+
+type ReadinessResponse struct {
+	health.Report
+	Build buildinfo.Info `json:"build"`
+}
+
 type WatchlistResponse struct {
 	Items      []*domain.Watchlist `json:"items"`
-	Total      int64               `json:"total"`
-	Page       int                 `json:"page"`
-	TotalPages int                 `json:"totalPages"`
+	NextCursor string              `json:"nextCursor,omitempty"`
+	Total      *int64              `json:"total,omitempty"`
+	Limit      int                 `json:"limit"`
 }
 
 type AddToWatchlistRequest struct {
@@ -53,9 +107,9 @@ type RatingRequest struct {
 
 type RatingsResponse struct {
 	Ratings    []*domain.Rating `json:"ratings"`
-	Total      int64            `json:"total"`
-	Page       int              `json:"page"`
-	TotalPages int              `json:"totalPages"`
+	NextCursor string           `json:"nextCursor,omitempty"`
+	Total      *int64           `json:"total,omitempty"`
+	Limit      int              `json:"limit"`
 }
 
 type CreateUserRequest struct {
@@ -71,11 +125,22 @@ type UpdateUserRequest struct {
 	FirstName string `json:"firstName,omitempty" binding:"omitempty,min=1,max=50"`
 	LastName  string `json:"lastName,omitempty" binding:"omitempty,min=1,max=50"`
 	Avatar    string `json:"avatar,omitempty"`
+	IsActive  *bool  `json:"isActive,omitempty"`
 }
 
 type UsersResponse struct {
-	Users  []*domain.User `json:"users"`
-	Total  int64          `json:"total"`
-	Limit  int            `json:"limit"`
-	Offset int            `json:"offset"`
+	Users      []*domain.User `json:"users"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+	Total      *int64         `json:"total,omitempty"`
+	Limit      int            `json:"limit"`
+}
+
+type AdminMovieResponse struct {
+	Movie    *domain.Movie           `json:"movie"`
+	Override *domain.CatalogOverride `json:"override,omitempty"`
+}
+
+type AdminTVShowResponse struct {
+	TVShow   *domain.TVShow          `json:"tvShow"`
+	Override *domain.CatalogOverride `json:"override,omitempty"`
 }
@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/internal/domain"
+	"backend/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserAdminHandler struct {
+	userAdminUseCase *usecase.UserAdminUseCase
+}
+
+func NewUserAdminHandler(userAdminUseCase *usecase.UserAdminUseCase) *UserAdminHandler {
+	return &UserAdminHandler{
+		userAdminUseCase: userAdminUseCase,
+	}
+}
+
+// ListUsers godoc
+// @Summary List users
+// @Description Page through the users that aren't deleted
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param limit query int false "Page size" default(20)
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param includeTotal query bool false "Count all users" default(false)
+// @Success 200 {object} UsersResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users [get]
+func (h *UserAdminHandler) ListUsers(c *gin.Context) {
+	page := pageRequest(c)
+	result, err := h.userAdminUseCase.ListUsers(c.Request.Context(), page)
+	if err == domain.ErrInvalidCursor {
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_cursor",
+			Message: "Invalid or expired page cursor",
+		})
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		RespondError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "fetch_error",
+			Message: "Failed to list users",
+		})
+		return
+	}
+
+	users := result.Items
+	if users == nil {
+		users = []*domain.User{}
+	}
+	c.JSON(http.StatusOK, UsersResponse{
+		Users:      users,
+		NextCursor: result.NextCursor,
+		Total:      result.Total,
+		Limit:      page.Limit,
+	})
+}
+
+// GetUser godoc
+// @Summary Get a user
+// @Description Get a user; the ETag header carries its version for If-Match
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "User ID"
+// @Success 200 {object} domain.User
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id} [get]
+func (h *UserAdminHandler) GetUser(c *gin.Context) {
+	user, err := h.userAdminUseCase.GetUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondUserAdminError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(user.Version))
+	c.JSON(http.StatusOK, user)
+}
+
+// CreateUser godoc
+// @Summary Create a user
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param user body CreateUserRequest true "New user"
+// @Success 201 {object} domain.User
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users [post]
+func (h *UserAdminHandler) CreateUser(c *gin.Context) {
+	var req CreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	user := &domain.User{
+		Email:     req.Email,
+		Username:  req.Username,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+	}
+	if err := h.userAdminUseCase.CreateUser(c.Request.Context(), user); err != nil {
+		respondUserAdminError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(user.Version))
+	c.JSON(http.StatusCreated, user)
+}
+
+// UpdateUser godoc
+// @Summary Update a user
+// @Description Change a user's profile; fields left out keep their value
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "User ID"
+// @Param If-Match header string true "ETags of the versions the update is based on, or *"
+// @Param user body UpdateUserRequest true "Fields to change"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
+// @Router /admin/users/{id} [patch]
+func (h *UserAdminHandler) UpdateUser(c *gin.Context) {
+	expected, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	user, err := h.userAdminUseCase.UpdateUser(c.Request.Context(), c.Param("id"), expected, usecase.UserUpdate{
+		Email:     req.Email,
+		Username:  req.Username,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Avatar:    req.Avatar,
+		IsActive:  req.IsActive,
+	})
+	if err != nil {
+		respondUserAdminError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(user.Version))
+	c.JSON(http.StatusOK, user)
+}
+
+// DeleteUser godoc
+// @Summary Delete a user
+// @Description Soft-delete a user; it can be restored until the purge job removes it
+// @Tags admin
+// @Security AdminToken
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id} [delete]
+func (h *UserAdminHandler) DeleteUser(c *gin.Context) {
+	if err := h.userAdminUseCase.DeleteUser(c.Request.Context(), c.Param("id")); err != nil {
+		respondUserAdminError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RestoreUser godoc
+// @Summary Restore a deleted user
+// @Description Bring back a soft-deleted user
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "User ID"
+// @Success 200 {object} domain.User
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/restore [post]
+func (h *UserAdminHandler) RestoreUser(c *gin.Context) {
+	user, err := h.userAdminUseCase.RestoreUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondUserAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// respondUserAdminError maps user admin use case errors to responses.
+func respondUserAdminError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound):
+		RespondError(c, http.StatusNotFound, ErrorResponse{
+			Error:   "user_not_found",
+			Message: "User not found",
+		})
+	case errors.Is(err, domain.ErrVersionConflict):
+		RespondError(c, http.StatusPreconditionFailed, ErrorResponse{
+			Error:   "version_conflict",
+			Message: "The user was changed since it was read; fetch it again and retry",
+		})
+	case errors.Is(err, usecase.ErrAlreadyExists):
+		RespondError(c, http.StatusConflict, ErrorResponse{
+			Error:   "user_exists",
+			Message: "Email or username already taken",
+		})
+	case errors.Is(err, usecase.ErrInvalidInput):
+		RespondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "At least one field must be changed",
+		})
+	default:
+		c.Error(err)
+		RespondError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "update_failed",
+			Message: "Failed to update user",
+		})
+	}
+}
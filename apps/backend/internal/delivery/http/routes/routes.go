@@ -2,62 +2,101 @@ package routes
 
 import (
 	"backend/config"
+	"backend/internal/buildinfo"
 	"backend/internal/delivery/http/handler"
-	"backend/internal/infrastructure/repository"
-	"backend/internal/infrastructure/service"
+	"backend/internal/domain"
 	"backend/internal/middleware"
+	"backend/internal/ratelimit"
 	"backend/internal/usecase"
+	"backend/internal/worker"
 	"fmt"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func SetupRoutes(router *gin.RouterGroup, db *mongo.Database, cfg *config.Config) {
-	// Initialize services
-	tmdbService := service.NewTMDBService(cfg)
+// Workers are the background jobs reachable from the admin API. Any of them
+// may be nil when disabled.
+type Workers struct {
+	Refresher   *worker.CatalogRefresher
+	ChangesSync *worker.ChangesSync
+	Purger      *worker.Purger
+}
 
+// SetupRoutes registers the API. Endpoints that need the database answer 503
+// while storage reports it unavailable; TMDB-backed ones keep working.
+// tmdbService is shared with the background workers so they all draw on
+// one response cache and request budget.
+func SetupRoutes(router *gin.RouterGroup, repos domain.Repositories, storage domain.StorageStatus, tmdbService domain.TMDBService, cfg *config.Config, workers Workers) {
 	// Initialize repositories
-	movieRepo := repository.NewMovieRepository(db)
-	// tvShowRepo := repository.NewTVShowRepository(db)
-	// userRepo := repository.NewUserRepository(db)
-	// watchlistRepo := repository.NewWatchlistRepository(db)
+	movieRepo := repos.Movies
+	tvShowRepo := repos.TVShows
+	overrideRepo := repos.Overrides
+	personRepo := repos.People
+	userRepo := repos.Users
+	// watchlistRepo := repos.Watchlists
 
 	// Initialize use cases
-	movieUseCase := usecase.NewMovieUseCase(movieRepo, tmdbService)
-	// tvShowUseCase := usecase.NewTVShowUseCase(tvShowRepo, tmdbService)
+	movieUseCase := usecase.NewMovieUseCase(movieRepo, overrideRepo, tmdbService, storage)
+	tvShowUseCase := usecase.NewTVShowUseCase(tvShowRepo, overrideRepo, tmdbService, storage)
+	personUseCase := usecase.NewPersonUseCase(personRepo, tmdbService, storage)
+	catalogAdminUseCase := usecase.NewCatalogAdminUseCase(movieRepo, tvShowRepo, overrideRepo, tmdbService)
+	userAdminUseCase := usecase.NewUserAdminUseCase(userRepo)
 	// watchlistUseCase := usecase.NewWatchlistUseCase(watchlistRepo, movieRepo, tvShowRepo)
 
 	// Initialize handlers
 	movieHandler := handler.NewMovieHandler(movieUseCase)
+	personHandler := handler.NewPersonHandler(personUseCase)
+	catalogHandler := handler.NewCatalogHandler(movieUseCase, tvShowUseCase)
+	adminHandler := handler.NewAdminHandler(workers.Refresher, workers.ChangesSync, workers.Purger)
+	catalogAdminHandler := handler.NewCatalogAdminHandler(catalogAdminUseCase)
+	userAdminHandler := handler.NewUserAdminHandler(userAdminUseCase)
+	configHandler := handler.NewConfigHandler(cfg)
 	// tvShowHandler := handler.NewTVShowHandler(tvShowUseCase)
 	// watchlistHandler := handler.NewWatchlistHandler(watchlistUseCase)
 
 	// Health check endpoint
 	router.GET("/health", func(c *gin.Context) {
+		status, database := "healthy", "connected"
+		if !storage.Available() {
+			status, database = "degraded", "disconnected"
+		}
 		c.JSON(200, handler.HealthResponse{
-			Status:    "healthy",
-			Timestamp: "2025-01-20T23:00:00Z",
-			Database:  "connected",
-			Version:   "1.0.0",
+			Status:    status,
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Database:  database,
+			Version:   buildinfo.Version,
 		})
 	})
 
 	// API v1 routes
 	v1 := router.Group("/v1")
-	v1.Use(middleware.RequestID())
 	v1.Use(middleware.ErrorHandler())
+	requireStorage := middleware.RequireStorage(storage)
+
+	// Callers presenting a user token are identified first, so the rate
+	// limits below count their requests per user rather than per IP
+	v1.Use(middleware.Authenticate(cfg.JWTSecret))
+
+	// Per-client rate limits: every request counts against the default
+	// group, searches against the tighter search group as well
+	rateLimit := func(string) gin.HandlerFunc { return func(c *gin.Context) { c.Next() } }
+	if cfg.RateLimitEnabled {
+		limiter := ratelimit.NewLimiter(cfg)
+		rateLimit = func(group string) gin.HandlerFunc {
+			return middleware.RateLimit(limiter, group, cfg.APIKeys)
+		}
+	}
+	v1.Use(rateLimit("default"))
+	searchLimit := rateLimit("search")
 
 	// Movie routes
 	movies := v1.Group("/movies")
 	{
-		movies.GET("/:id", movieHandler.GetMovie)
+		movies.GET("/:id", requireStorage, movieHandler.GetMovie)
 		movies.GET("/tmdb/:tmdb_id", movieHandler.GetMovieByTMDBID)
-		movies.GET("/search", movieHandler.SearchMovies)
-		movies.GET("/popular", func(c *gin.Context) {
-			fmt.Println("🔥 Movies popular endpoint hit!")
-			movieHandler.GetPopularMovies(c)
-		})
+		movies.GET("/search", searchLimit, movieHandler.SearchMovies)
+		movies.GET("/popular", movieHandler.GetPopularMovies)
 		movies.GET("/genre/:genre_id", movieHandler.GetMoviesByGenre)
 	}
 
@@ -65,18 +104,16 @@ func SetupRoutes(router *gin.RouterGroup, db *mongo.Database, cfg *config.Config
 	tvShows := v1.Group("/tv")
 	{
 		tvShows.GET("/popular", func(c *gin.Context) {
-			fmt.Println("🔥 TV Shows popular endpoint hit!")
 			page := 1 // Default to page 1
 			shows, totalPages, err := tmdbService.GetPopularTVShows(c.Request.Context(), page)
 			if err != nil {
-				fmt.Printf("❌ Error fetching TV shows: %v\n", err)
-				c.JSON(500, handler.ErrorResponse{
+				c.Error(err)
+				handler.RespondError(c, 500, handler.ErrorResponse{
 					Error:   "fetch_error",
 					Message: "Failed to fetch popular TV shows",
 				})
 				return
 			}
-			fmt.Printf("✅ Successfully fetched %d TV shows\n", len(shows))
 			c.JSON(200, gin.H{
 				"results":     shows,
 				"page":        page,
@@ -84,10 +121,10 @@ func SetupRoutes(router *gin.RouterGroup, db *mongo.Database, cfg *config.Config
 			})
 		})
 
-		tvShows.GET("/search", func(c *gin.Context) {
+		tvShows.GET("/search", searchLimit, func(c *gin.Context) {
 			query := c.Query("q")
 			if query == "" {
-				c.JSON(400, handler.ErrorResponse{
+				handler.RespondError(c, 400, handler.ErrorResponse{
 					Error:   "missing_query",
 					Message: "Query parameter 'q' is required",
 				})
@@ -97,7 +134,8 @@ func SetupRoutes(router *gin.RouterGroup, db *mongo.Database, cfg *config.Config
 			page := 1 // Default to page 1
 			results, totalPages, err := tmdbService.SearchTVShows(c.Request.Context(), query, page)
 			if err != nil {
-				c.JSON(500, handler.ErrorResponse{
+				c.Error(err)
+				handler.RespondError(c, 500, handler.ErrorResponse{
 					Error:   "search_error",
 					Message: "Failed to search TV shows",
 				})
@@ -116,7 +154,7 @@ func SetupRoutes(router *gin.RouterGroup, db *mongo.Database, cfg *config.Config
 			// Convert string to int
 			var tmdbID int
 			if _, err := fmt.Sscanf(id, "%d", &tmdbID); err != nil {
-				c.JSON(400, handler.ErrorResponse{
+				handler.RespondError(c, 400, handler.ErrorResponse{
 					Error:   "invalid_id",
 					Message: "TV show ID must be a number",
 				})
@@ -125,7 +163,7 @@ func SetupRoutes(router *gin.RouterGroup, db *mongo.Database, cfg *config.Config
 
 			show, err := tmdbService.GetTVShow(c.Request.Context(), tmdbID)
 			if err != nil {
-				c.JSON(404, handler.ErrorResponse{
+				handler.RespondError(c, 404, handler.ErrorResponse{
 					Error:   "not_found",
 					Message: "TV show not found",
 				})
@@ -135,6 +173,19 @@ func SetupRoutes(router *gin.RouterGroup, db *mongo.Database, cfg *config.Config
 		})
 	}
 
+	// People routes
+	v1.GET("/people/tmdb/:tmdb_id", personHandler.GetPersonByTMDBID)
+
+	// Local catalog routes
+	catalog := v1.Group("/catalog")
+	catalog.Use(requireStorage)
+	{
+		catalog.GET("/movies", catalogHandler.ListMovies)
+		catalog.GET("/movies/search", searchLimit, catalogHandler.SearchMovies)
+		catalog.GET("/tv", catalogHandler.ListTVShows)
+		catalog.GET("/tv/search", searchLimit, catalogHandler.SearchTVShows)
+	}
+
 	// Watchlist routes (placeholder)
 	watchlist := v1.Group("/watchlist")
 	{
@@ -143,11 +194,51 @@ func SetupRoutes(router *gin.RouterGroup, db *mongo.Database, cfg *config.Config
 		})
 	}
 
+	// Admin routes
+	admin := v1.Group("/admin")
+	admin.Use(middleware.AdminAuth(cfg.AdminToken))
+	{
+		admin.GET("/refresh", adminHandler.GetRefreshStatus)
+		admin.GET("/sync", adminHandler.GetSyncStatus)
+		admin.POST("/sync", adminHandler.TriggerSync)
+		admin.GET("/purge", adminHandler.GetPurgeStatus)
+		admin.GET("/config", configHandler.GetConfigStatus)
+	}
+
+	adminStorage := admin.Group("")
+	adminStorage.Use(requireStorage)
+	{
+		adminStorage.GET("/movies/:id", catalogAdminHandler.GetMovie)
+		adminStorage.PATCH("/movies/:id", catalogAdminHandler.EditMovie)
+		adminStorage.DELETE("/movies/:id/override", catalogAdminHandler.ResetMovie)
+		adminStorage.POST("/movies/:id/hide", catalogAdminHandler.HideMovie)
+		adminStorage.POST("/movies/:id/unhide", catalogAdminHandler.UnhideMovie)
+		adminStorage.DELETE("/movies/:id", catalogAdminHandler.DeleteMovie)
+		adminStorage.POST("/movies/:id/restore", catalogAdminHandler.RestoreMovie)
+
+		adminStorage.GET("/tv/:id", catalogAdminHandler.GetTVShow)
+		adminStorage.PATCH("/tv/:id", catalogAdminHandler.EditTVShow)
+		adminStorage.DELETE("/tv/:id/override", catalogAdminHandler.ResetTVShow)
+		adminStorage.POST("/tv/:id/hide", catalogAdminHandler.HideTVShow)
+		adminStorage.POST("/tv/:id/unhide", catalogAdminHandler.UnhideTVShow)
+		adminStorage.DELETE("/tv/:id", catalogAdminHandler.DeleteTVShow)
+		adminStorage.POST("/tv/:id/restore", catalogAdminHandler.RestoreTVShow)
+
+		adminStorage.GET("/users", userAdminHandler.ListUsers)
+		adminStorage.POST("/users", userAdminHandler.CreateUser)
+		adminStorage.GET("/users/:id", userAdminHandler.GetUser)
+		adminStorage.PATCH("/users/:id", userAdminHandler.UpdateUser)
+		// Deleted users, like deleted titles, can be restored until the
+		// purger removes them with their watchlist and ratings
+		adminStorage.DELETE("/users/:id", userAdminHandler.DeleteUser)
+		adminStorage.POST("/users/:id/restore", userAdminHandler.RestoreUser)
+	}
+
 	// Genres endpoint
 	v1.GET("/genres/:media_type", func(c *gin.Context) {
 		mediaType := c.Param("media_type")
 		if mediaType != "movie" && mediaType != "tv" {
-			c.JSON(400, handler.ErrorResponse{
+			handler.RespondError(c, 400, handler.ErrorResponse{
 				Error:   "invalid_media_type",
 				Message: "Media type must be 'movie' or 'tv'",
 			})
@@ -156,7 +247,8 @@ func SetupRoutes(router *gin.RouterGroup, db *mongo.Database, cfg *config.Config
 
 		genres, err := tmdbService.GetGenres(c.Request.Context(), mediaType)
 		if err != nil {
-			c.JSON(500, handler.ErrorResponse{
+			c.Error(err)
+			handler.RespondError(c, 500, handler.ErrorResponse{
 				Error:   "fetch_error",
 				Message: "Failed to fetch genres",
 			})
@@ -24,8 +24,11 @@ type Movie struct {
 	Revenue      int64              `json:"revenue" bson:"revenue"`
 	Status       string             `json:"status" bson:"status"`
 	Tagline      string             `json:"tagline" bson:"tagline"`
+	Hidden       bool               `json:"hidden,omitempty" bson:"hidden"`
 	CreatedAt    time.Time          `json:"createdAt" bson:"createdAt"`
 	UpdatedAt    time.Time          `json:"updatedAt" bson:"updatedAt"`
+	Version      int64              `json:"version" bson:"version"`
+	DeletedAt    *time.Time         `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
 }
 
 // TVShow represents a TV show entity
@@ -45,8 +48,57 @@ type TVShow struct {
 	Genres           []Genre            `json:"genres" bson:"genres"`
 	Status           string             `json:"status" bson:"status"`
 	Type             string             `json:"type" bson:"type"`
+	Hidden           bool               `json:"hidden,omitempty" bson:"hidden"`
 	CreatedAt        time.Time          `json:"createdAt" bson:"createdAt"`
 	UpdatedAt        time.Time          `json:"updatedAt" bson:"updatedAt"`
+	Version          int64              `json:"version" bson:"version"`
+	DeletedAt        *time.Time         `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+}
+
+// Person represents a cast or crew member fetched from TMDB
+type Person struct {
+	ID                 primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TMDBPersonID       int                `json:"tmdbPersonId" bson:"tmdbPersonId"`
+	Name               string             `json:"name" bson:"name"`
+	Biography          string             `json:"biography" bson:"biography"`
+	ProfilePath        string             `json:"profilePath" bson:"profilePath"`
+	KnownForDepartment string             `json:"knownForDepartment" bson:"knownForDepartment"`
+	Birthday           string             `json:"birthday" bson:"birthday"`
+	Deathday           string             `json:"deathday,omitempty" bson:"deathday"`
+	PlaceOfBirth       string             `json:"placeOfBirth" bson:"placeOfBirth"`
+	Popularity         float64            `json:"popularity" bson:"popularity"`
+	CreatedAt          time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt          time.Time          `json:"updatedAt" bson:"updatedAt"`
+	Version            int64              `json:"version" bson:"version"`
+}
+
+// MovieSearchResult is a movie matched by a local catalog search along
+// with its text relevance score
+type MovieSearchResult struct {
+	Movie `bson:",inline"`
+	Score float64 `json:"score" bson:"score"`
+}
+
+// TVShowSearchResult is a TV show matched by a local catalog search along
+// with its text relevance score
+type TVShowSearchResult struct {
+	TVShow `bson:",inline"`
+	Score  float64 `json:"score" bson:"score"`
+}
+
+// CatalogSort orders a catalog listing
+type CatalogSort string
+
+const (
+	SortByVote        CatalogSort = "vote"
+	SortByReleaseDate CatalogSort = "release_date"
+	SortByRecent      CatalogSort = "recent"
+)
+
+// CatalogQuery filters and orders a listing of the stored catalog
+type CatalogQuery struct {
+	GenreID int // 0 matches every genre
+	Sort    CatalogSort
 }
 
 // Genre represents a genre entity
@@ -66,6 +118,8 @@ type User struct {
 	IsActive  bool               `json:"isActive" bson:"isActive"`
 	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
 	UpdatedAt time.Time          `json:"updatedAt" bson:"updatedAt"`
+	Version   int64              `json:"version" bson:"version"`
+	DeletedAt *time.Time         `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
 }
 
 // Watchlist represents a user's watchlist
@@ -90,3 +144,43 @@ type Rating struct {
 	CreatedAt time.Time           `json:"createdAt" bson:"createdAt"`
 	UpdatedAt time.Time           `json:"updatedAt" bson:"updatedAt"`
 }
+
+// CatalogOverride holds curated values for a movie or TV show that take
+// precedence over TMDB data. It is stored apart from the title itself so a
+// refresh from TMDB never loses it.
+type CatalogOverride struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	MediaType string             `json:"mediaType" bson:"mediaType"` // "movie" or "tv"
+	TMDBID    int                `json:"tmdbId" bson:"tmdbId"`
+	Fields    OverrideFields     `json:"fields" bson:"fields"`
+	UpdatedAt time.Time          `json:"updatedAt" bson:"updatedAt"`
+}
+
+// OverrideFields are the curated values of a CatalogOverride. Nil fields
+// keep the upstream value. Title and ReleaseDate map to a TV show's name and
+// first air date; Tagline only applies to movies.
+type OverrideFields struct {
+	Title        *string  `json:"title,omitempty" bson:"title,omitempty"`
+	Overview     *string  `json:"overview,omitempty" bson:"overview,omitempty"`
+	Tagline      *string  `json:"tagline,omitempty" bson:"tagline,omitempty"`
+	PosterPath   *string  `json:"posterPath,omitempty" bson:"posterPath,omitempty"`
+	BackdropPath *string  `json:"backdropPath,omitempty" bson:"backdropPath,omitempty"`
+	ReleaseDate  *string  `json:"releaseDate,omitempty" bson:"releaseDate,omitempty"`
+	Status       *string  `json:"status,omitempty" bson:"status,omitempty"`
+	Genres       *[]Genre `json:"genres,omitempty" bson:"genres,omitempty"`
+}
+
+// Checkpoint records how far a background job has progressed
+type Checkpoint struct {
+	Name          string    `json:"name" bson:"_id"`
+	SyncedThrough time.Time `json:"syncedThrough" bson:"syncedThrough"`
+	Offset        int64     `json:"offset,omitempty" bson:"offset,omitempty"`
+	UpdatedAt     time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// RefreshPosition marks how far a refresh pass has walked a collection
+// in (updatedAt, _id) order. The zero value starts from the beginning.
+type RefreshPosition struct {
+	UpdatedAt time.Time
+	ID        primitive.ObjectID
+}
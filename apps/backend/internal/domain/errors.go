@@ -0,0 +1,11 @@
+package domain
+
+import "errors"
+
+// ErrNotFound is returned by repositories when the requested record doesn't
+// exist or has been deleted.
+var ErrNotFound = errors.New("not found")
+
+// ErrVersionConflict is returned when an update was based on a version of a
+// record that has since been changed by someone else.
+var ErrVersionConflict = errors.New("version conflict")
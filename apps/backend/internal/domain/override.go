@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// PatchField is one field of a JSON merge patch: left out, null to drop
+// the curated value, or a new value.
+type PatchField[T any] struct {
+	Set   bool
+	Value *T
+}
+
+func (p *PatchField[T]) UnmarshalJSON(data []byte) error {
+	p.Set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		p.Value = nil
+		return nil
+	}
+	p.Value = new(T)
+	return json.Unmarshal(data, p.Value)
+}
+
+// OverridePatch edits the curated values of a CatalogOverride. Fields left
+// out keep their curated value; fields set to null fall back to upstream.
+type OverridePatch struct {
+	Title        PatchField[string]  `json:"title"`
+	Overview     PatchField[string]  `json:"overview"`
+	Tagline      PatchField[string]  `json:"tagline"`
+	PosterPath   PatchField[string]  `json:"posterPath"`
+	BackdropPath PatchField[string]  `json:"backdropPath"`
+	ReleaseDate  PatchField[string]  `json:"releaseDate"`
+	Status       PatchField[string]  `json:"status"`
+	Genres       PatchField[[]Genre] `json:"genres"`
+}
+
+// Empty reports whether the patch touches no field.
+func (p OverridePatch) Empty() bool {
+	return !p.Title.Set && !p.Overview.Set && !p.Tagline.Set && !p.PosterPath.Set &&
+		!p.BackdropPath.Set && !p.ReleaseDate.Set && !p.Status.Set && !p.Genres.Set
+}
+
+// Clears reports whether the patch drops any curated value.
+func (p OverridePatch) Clears() bool {
+	return cleared(p.Title) || cleared(p.Overview) || cleared(p.Tagline) || cleared(p.PosterPath) ||
+		cleared(p.BackdropPath) || cleared(p.ReleaseDate) || cleared(p.Status) || cleared(p.Genres)
+}
+
+// Apply sets or drops the curated values the patch touches.
+func (f *OverrideFields) Apply(p OverridePatch) {
+	patch(&f.Title, p.Title)
+	patch(&f.Overview, p.Overview)
+	patch(&f.Tagline, p.Tagline)
+	patch(&f.PosterPath, p.PosterPath)
+	patch(&f.BackdropPath, p.BackdropPath)
+	patch(&f.ReleaseDate, p.ReleaseDate)
+	patch(&f.Status, p.Status)
+	patch(&f.Genres, p.Genres)
+}
+
+func patch[T any](field **T, p PatchField[T]) {
+	if p.Set {
+		*field = p.Value
+	}
+}
+
+func cleared[T any](p PatchField[T]) bool {
+	return p.Set && p.Value == nil
+}
+
+// ApplyOverride replaces upstream values of m with the curated ones in o.
+// A nil override leaves m unchanged.
+func (m *Movie) ApplyOverride(o *CatalogOverride) {
+	if o == nil {
+		return
+	}
+	f := o.Fields
+	if f.Title != nil {
+		m.Title = *f.Title
+	}
+	if f.Overview != nil {
+		m.Overview = *f.Overview
+	}
+	if f.Tagline != nil {
+		m.Tagline = *f.Tagline
+	}
+	if f.PosterPath != nil {
+		m.PosterPath = *f.PosterPath
+	}
+	if f.BackdropPath != nil {
+		m.BackdropPath = *f.BackdropPath
+	}
+	if f.ReleaseDate != nil {
+		m.ReleaseDate = *f.ReleaseDate
+	}
+	if f.Status != nil {
+		m.Status = *f.Status
+	}
+	if f.Genres != nil {
+		m.Genres = *f.Genres
+	}
+}
+
+// ApplyOverride replaces upstream values of t with the curated ones in o.
+// A nil override leaves t unchanged.
+func (t *TVShow) ApplyOverride(o *CatalogOverride) {
+	if o == nil {
+		return
+	}
+	f := o.Fields
+	if f.Title != nil {
+		t.Name = *f.Title
+	}
+	if f.Overview != nil {
+		t.Overview = *f.Overview
+	}
+	if f.PosterPath != nil {
+		t.PosterPath = *f.PosterPath
+	}
+	if f.BackdropPath != nil {
+		t.BackdropPath = *f.BackdropPath
+	}
+	if f.ReleaseDate != nil {
+		t.FirstAirDate = *f.ReleaseDate
+	}
+	if f.Status != nil {
+		t.Status = *f.Status
+	}
+	if f.Genres != nil {
+		t.Genres = *f.Genres
+	}
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "errors"
+
+// ErrInvalidCursor is returned when a page cursor can't be decoded or was
+// issued for a different listing.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageRequest asks for one page of a keyset-paginated listing. Cursor is the
+// NextCursor of the previous page; an empty cursor starts at the beginning.
+type PageRequest struct {
+	Cursor    string
+	Limit     int
+	WithTotal bool
+}
+
+// Page is one page of a keyset-paginated listing. NextCursor is empty on the
+// last page and Total is only counted when the request asked for it.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+	Total      *int64
+}
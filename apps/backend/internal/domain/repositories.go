@@ -1,34 +1,68 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
-// MovieRepository defines movie data access interface
+// MovieRepository defines movie data access interface. Lookups return
+// ErrNotFound for missing records. Delete is a soft delete: deleted movies
+// are left out of every query until restored. ListDeleted finds those
+// deleted before a cutoff and Purge removes them for good, skipping any
+// restored or deleted again since; it returns the IDs no longer stored.
+// GetTMDBIDs maps IDs, soft-deleted ones included, to their TMDB IDs, leaving
+// out those not stored. Update fails with ErrVersionConflict when the stored
+// version differs from the given one.
+// Upsert stores a full TMDB fetch while UpsertMany stores list results and
+// refreshes only the fields those carry; neither bumps the version of a
+// record it leaves unchanged. Search counts every match only when withTotal
+// is set.
 type MovieRepository interface {
 	GetByID(ctx context.Context, id string) (*Movie, error)
 	GetByTMDBID(ctx context.Context, tmdbID int) (*Movie, error)
+	GetByTMDBIDs(ctx context.Context, tmdbIDs []int) ([]*Movie, error)
+	GetTMDBIDs(ctx context.Context, ids []string) (map[string]int, error)
 	Create(ctx context.Context, movie *Movie) error
+	Upsert(ctx context.Context, movie *Movie) error
+	UpsertMany(ctx context.Context, movies []*Movie) error
 	Update(ctx context.Context, movie *Movie) error
 	Delete(ctx context.Context, id string) error
-	List(ctx context.Context, limit, offset int) ([]*Movie, int64, error)
-	Search(ctx context.Context, query string, limit, offset int) ([]*Movie, int64, error)
-	GetByGenre(ctx context.Context, genreID int, limit, offset int) ([]*Movie, int64, error)
-	GetPopular(ctx context.Context, limit, offset int) ([]*Movie, int64, error)
+	List(ctx context.Context, page PageRequest) (*Page[*Movie], error)
+	Search(ctx context.Context, query string, limit, offset int, withTotal bool) ([]*MovieSearchResult, *int64, error)
+	GetByGenre(ctx context.Context, genreID int, page PageRequest) (*Page[*Movie], error)
+	GetPopular(ctx context.Context, page PageRequest) (*Page[*Movie], error)
+	Browse(ctx context.Context, query CatalogQuery, page PageRequest) (*Page[*Movie], error)
+	ListStale(ctx context.Context, updatedBefore time.Time, after RefreshPosition, limit int) ([]*Movie, error)
+	Restore(ctx context.Context, id string) error
+	ListDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error)
+	Purge(ctx context.Context, ids []string, deletedBefore time.Time) ([]string, error)
 }
 
-// TVShowRepository defines TV show data access interface
+// TVShowRepository defines TV show data access interface. Lookups, deletes
+// and updates behave as for MovieRepository.
 type TVShowRepository interface {
 	GetByID(ctx context.Context, id string) (*TVShow, error)
 	GetByTMDBID(ctx context.Context, tmdbID int) (*TVShow, error)
+	GetByTMDBIDs(ctx context.Context, tmdbIDs []int) ([]*TVShow, error)
+	GetTMDBIDs(ctx context.Context, ids []string) (map[string]int, error)
 	Create(ctx context.Context, tvShow *TVShow) error
+	Upsert(ctx context.Context, tvShow *TVShow) error
+	UpsertMany(ctx context.Context, tvShows []*TVShow) error
 	Update(ctx context.Context, tvShow *TVShow) error
 	Delete(ctx context.Context, id string) error
-	List(ctx context.Context, limit, offset int) ([]*TVShow, int64, error)
-	Search(ctx context.Context, query string, limit, offset int) ([]*TVShow, int64, error)
-	GetByGenre(ctx context.Context, genreID int, limit, offset int) ([]*TVShow, int64, error)
-	GetPopular(ctx context.Context, limit, offset int) ([]*TVShow, int64, error)
+	List(ctx context.Context, page PageRequest) (*Page[*TVShow], error)
+	Search(ctx context.Context, query string, limit, offset int, withTotal bool) ([]*TVShowSearchResult, *int64, error)
+	GetByGenre(ctx context.Context, genreID int, page PageRequest) (*Page[*TVShow], error)
+	GetPopular(ctx context.Context, page PageRequest) (*Page[*TVShow], error)
+	Browse(ctx context.Context, query CatalogQuery, page PageRequest) (*Page[*TVShow], error)
+	ListStale(ctx context.Context, updatedBefore time.Time, after RefreshPosition, limit int) ([]*TVShow, error)
+	Restore(ctx context.Context, id string) error
+	ListDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error)
+	Purge(ctx context.Context, ids []string, deletedBefore time.Time) ([]string, error)
 }
 
-// UserRepository defines user data access interface
+// UserRepository defines user data access interface. Lookups, deletes and
+// updates behave as for MovieRepository.
 type UserRepository interface {
 	GetByID(ctx context.Context, id string) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
@@ -36,32 +70,87 @@ type UserRepository interface {
 	Create(ctx context.Context, user *User) error
 	Update(ctx context.Context, user *User) error
 	Delete(ctx context.Context, id string) error
-	List(ctx context.Context, limit, offset int) ([]*User, int64, error)
+	List(ctx context.Context, page PageRequest) (*Page[*User], error)
+	Restore(ctx context.Context, id string) error
+	ListDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error)
+	Purge(ctx context.Context, ids []string, deletedBefore time.Time) ([]string, error)
 }
 
 // WatchlistRepository defines watchlist data access interface
 type WatchlistRepository interface {
-	GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*Watchlist, int64, error)
+	GetByUserID(ctx context.Context, userID string, page PageRequest) (*Page[*Watchlist], error)
 	Add(ctx context.Context, watchlist *Watchlist) error
 	Remove(ctx context.Context, userID, itemID string, itemType string) error
 	IsInWatchlist(ctx context.Context, userID, itemID string, itemType string) (bool, error)
+	RemoveByItems(ctx context.Context, itemIDs []string, itemType string) error
+	RemoveByUsers(ctx context.Context, userIDs []string) error
 }
 
 // RatingRepository defines rating data access interface
 type RatingRepository interface {
 	GetByUserAndItem(ctx context.Context, userID, itemID string, itemType string) (*Rating, error)
-	GetByItem(ctx context.Context, itemID string, itemType string, limit, offset int) ([]*Rating, int64, error)
-	GetByUser(ctx context.Context, userID string, limit, offset int) ([]*Rating, int64, error)
+	GetByItem(ctx context.Context, itemID string, itemType string, page PageRequest) (*Page[*Rating], error)
+	GetByUser(ctx context.Context, userID string, page PageRequest) (*Page[*Rating], error)
 	Create(ctx context.Context, rating *Rating) error
 	Update(ctx context.Context, rating *Rating) error
 	Delete(ctx context.Context, id string) error
 	GetAverageRating(ctx context.Context, itemID string, itemType string) (float64, int64, error)
+	DeleteByItems(ctx context.Context, itemIDs []string, itemType string) error
+	DeleteByUsers(ctx context.Context, userIDs []string) error
+}
+
+// PersonRepository stores the people fetched from TMDB, keyed on their TMDB
+// ID. Upsert inserts a new person or refreshes a stored one, copying the
+// stored ID, CreatedAt and Version back; the version only moves when a field
+// changed.
+type PersonRepository interface {
+	GetByTMDBID(ctx context.Context, tmdbID int) (*Person, error)
+	GetByTMDBIDs(ctx context.Context, tmdbIDs []int) ([]*Person, error)
+	Upsert(ctx context.Context, person *Person) error
+}
+
+// OverrideRepository stores curated catalog overrides keyed by media type
+// and TMDB ID. Get returns nil without an error when no override exists.
+type OverrideRepository interface {
+	Get(ctx context.Context, mediaType string, tmdbID int) (*CatalogOverride, error)
+	GetMany(ctx context.Context, mediaType string, tmdbIDs []int) ([]*CatalogOverride, error)
+	Save(ctx context.Context, override *CatalogOverride) error
+	Delete(ctx context.Context, mediaType string, tmdbID int) error
+	DeleteMany(ctx context.Context, mediaType string, tmdbIDs []int) error
+}
+
+// CheckpointRepository stores progress markers for background jobs.
+// Get returns nil without an error when no checkpoint has been saved yet.
+type CheckpointRepository interface {
+	Get(ctx context.Context, name string) (*Checkpoint, error)
+	Save(ctx context.Context, checkpoint *Checkpoint) error
+}
+
+// Repositories bundles every repository the application uses, all backed by
+// the same storage driver.
+type Repositories struct {
+	Movies      MovieRepository
+	TVShows     TVShowRepository
+	People      PersonRepository
+	Users       UserRepository
+	Watchlists  WatchlistRepository
+	Ratings     RatingRepository
+	Overrides   OverrideRepository
+	Checkpoints CheckpointRepository
+}
+
+// StorageStatus reports whether the repositories can currently reach their
+// backing store. While it can't, the API runs in degraded mode: TMDB-backed
+// reads are served without being persisted and everything else fails fast.
+type StorageStatus interface {
+	Available() bool
 }
 
 // TMDBService defines external TMDB API interface
 type TMDBService interface {
 	GetMovie(ctx context.Context, movieID int) (*Movie, error)
 	GetTVShow(ctx context.Context, tvShowID int) (*TVShow, error)
+	GetPerson(ctx context.Context, personID int) (*Person, error)
 	SearchMovies(ctx context.Context, query string, page int) ([]*Movie, int, error)
 	SearchTVShows(ctx context.Context, query string, page int) ([]*TVShow, int, error)
 	GetPopularMovies(ctx context.Context, page int) ([]*Movie, int, error)
@@ -69,4 +158,5 @@ type TMDBService interface {
 	GetMoviesByGenre(ctx context.Context, genreID int, page int) ([]*Movie, int, error)
 	GetTVShowsByGenre(ctx context.Context, genreID int, page int) ([]*TVShow, int, error)
 	GetGenres(ctx context.Context, mediaType string) ([]Genre, error)
+	GetChanges(ctx context.Context, mediaType string, startDate, endDate time.Time, page int) ([]int, int, error)
 }
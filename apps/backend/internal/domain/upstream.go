@@ -0,0 +1,69 @@
+package domain
+
+// ApplyUpstream copies the TMDB-sourced fields of upstream onto m, leaving
+// identity and bookkeeping fields untouched.
+func (m *Movie) ApplyUpstream(upstream *Movie) {
+	m.TMDBMovieID = upstream.TMDBMovieID
+	m.Title = upstream.Title
+	m.Overview = upstream.Overview
+	m.PosterPath = upstream.PosterPath
+	m.BackdropPath = upstream.BackdropPath
+	m.ReleaseDate = upstream.ReleaseDate
+	m.Runtime = upstream.Runtime
+	m.VoteAverage = upstream.VoteAverage
+	m.VoteCount = upstream.VoteCount
+	m.Genres = upstream.Genres
+	m.Adult = upstream.Adult
+	m.Budget = upstream.Budget
+	m.Revenue = upstream.Revenue
+	m.Status = upstream.Status
+	m.Tagline = upstream.Tagline
+}
+
+// ApplyUpstream copies the TMDB-sourced fields of upstream onto t, leaving
+// identity and bookkeeping fields untouched.
+func (t *TVShow) ApplyUpstream(upstream *TVShow) {
+	t.TMDBTVShowID = upstream.TMDBTVShowID
+	t.Name = upstream.Name
+	t.Overview = upstream.Overview
+	t.PosterPath = upstream.PosterPath
+	t.BackdropPath = upstream.BackdropPath
+	t.FirstAirDate = upstream.FirstAirDate
+	t.LastAirDate = upstream.LastAirDate
+	t.NumberOfSeasons = upstream.NumberOfSeasons
+	t.NumberOfEpisodes = upstream.NumberOfEpisodes
+	t.VoteAverage = upstream.VoteAverage
+	t.VoteCount = upstream.VoteCount
+	t.Genres = upstream.Genres
+	t.Status = upstream.Status
+	t.Type = upstream.Type
+}
+
+// ApplyListing copies the fields TMDB list results carry for a movie onto m.
+// Details such as runtime, budget and genres only come with a full fetch and
+// are left as stored.
+func (m *Movie) ApplyListing(upstream *Movie) {
+	m.TMDBMovieID = upstream.TMDBMovieID
+	m.Title = upstream.Title
+	m.Overview = upstream.Overview
+	m.PosterPath = upstream.PosterPath
+	m.BackdropPath = upstream.BackdropPath
+	m.ReleaseDate = upstream.ReleaseDate
+	m.VoteAverage = upstream.VoteAverage
+	m.VoteCount = upstream.VoteCount
+	m.Adult = upstream.Adult
+}
+
+// ApplyListing copies the fields TMDB list results carry for a TV show onto
+// t. Details such as seasons, episodes and genres only come with a full
+// fetch and are left as stored.
+func (t *TVShow) ApplyListing(upstream *TVShow) {
+	t.TMDBTVShowID = upstream.TMDBTVShowID
+	t.Name = upstream.Name
+	t.Overview = upstream.Overview
+	t.PosterPath = upstream.PosterPath
+	t.BackdropPath = upstream.BackdropPath
+	t.FirstAirDate = upstream.FirstAirDate
+	t.VoteAverage = upstream.VoteAverage
+	t.VoteCount = upstream.VoteCount
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "slices"
+
+// VersionCondition is the record version an update was based on, as sent in
+// an If-Match header: any version, or one of a list.
+type VersionCondition struct {
+	Any      bool
+	Versions []int64
+}
+
+// Matches reports whether a record at version meets the condition.
+func (c VersionCondition) Matches(version int64) bool {
+	return c.Any || slices.Contains(c.Versions, version)
+}
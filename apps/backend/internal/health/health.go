@@ -0,0 +1,138 @@
+// Package health runs the dependency checks behind the readiness probe.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Check and report states.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	// StatusOK means every check is up
+	StatusOK = "ok"
+	// StatusDegraded means some checks are down but requests can still be
+	// served, e.g. from TMDB while MongoDB is unreachable
+	StatusDegraded = "degraded"
+	// StatusUnavailable means every required check is down
+	StatusUnavailable = "unavailable"
+)
+
+// CheckFunc probes one dependency. A non-nil error marks it down; detail,
+// when not nil, is reported either way.
+type CheckFunc func(ctx context.Context) (detail any, err error)
+
+// Result is the outcome of one check.
+type Result struct {
+	Status    string    `json:"status"`
+	Required  bool      `json:"required"`
+	Error     string    `json:"error,omitempty"`
+	Detail    any       `json:"detail,omitempty"`
+	LatencyMs float64   `json:"latencyMs"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// Report is the outcome of every check.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+type check struct {
+	name     string
+	required bool
+	fn       CheckFunc
+}
+
+// Checker runs a fixed set of checks concurrently, each bounded by timeout.
+type Checker struct {
+	timeout time.Duration
+	checks  []check
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Add registers a check. Required checks are the dependencies requests are
+// served from; optional ones are reported but never make the service
+// unavailable.
+func (c *Checker) Add(name string, required bool, fn CheckFunc) {
+	c.checks = append(c.checks, check{name: name, required: required, fn: fn})
+}
+
+// Run runs every check. The report is unavailable when all required checks
+// are down, degraded when any check is down and ok otherwise.
+func (c *Checker) Run(ctx context.Context) Report {
+	results := make([]Result, len(c.checks))
+	var wg sync.WaitGroup
+	for i, chk := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, chk)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(results))}
+	required, requiredDown := 0, 0
+	for i, result := range results {
+		report.Checks[c.checks[i].name] = result
+		if result.Required {
+			required++
+		}
+		if result.Status == StatusDown {
+			report.Status = StatusDegraded
+			if result.Required {
+				requiredDown++
+			}
+		}
+	}
+	if required > 0 && requiredDown == required {
+		report.Status = StatusUnavailable
+	}
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, chk check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	detail, err := chk.fn(ctx)
+	result := Result{
+		Status:    StatusUp,
+		Required:  chk.required,
+		Detail:    detail,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+		CheckedAt: start.UTC(),
+	}
+	if err != nil {
+		result.Status, result.Error = StatusDown, err.Error()
+	}
+	return result
+}
+
+// Cached reuses the outcome of fn for ttl, for checks that call out to
+// services that shouldn't be hit on every probe.
+func Cached(fn CheckFunc, ttl time.Duration) CheckFunc {
+	var (
+		mu        sync.Mutex
+		checkedAt time.Time
+		detail    any
+		err       error
+	)
+	return func(ctx context.Context) (any, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if checkedAt.IsZero() || time.Since(checkedAt) >= ttl {
+			detail, err = fn(ctx)
+			checkedAt = time.Now()
+		}
+		return detail, err
+	}
+}
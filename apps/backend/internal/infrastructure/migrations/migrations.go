@@ -0,0 +1,137 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrSchemaTooNew is returned when the database has migrations applied that
+// this build doesn't know about, i.e. it was migrated by a newer release.
+var ErrSchemaTooNew = errors.New("database schema is newer than this build")
+
+// Migration is one versioned, idempotent schema change.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+// AppliedMigration is the record kept in schema_migrations for each
+// migration that has run.
+type AppliedMigration struct {
+	Version     int       `json:"version" bson:"_id"`
+	Description string    `json:"description" bson:"description"`
+	AppliedAt   time.Time `json:"appliedAt" bson:"appliedAt"`
+}
+
+// Status describes how the database schema compares to this build.
+type Status struct {
+	Current int                `json:"current"`
+	Latest  int                `json:"latest"`
+	Applied []AppliedMigration `json:"applied"`
+	Pending []int              `json:"pending"`
+}
+
+type Runner struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+	migrations []Migration
+}
+
+// NewRunner returns a runner for the migrations registered in this package.
+func NewRunner(db *mongo.Database) *Runner {
+	migrations := append([]Migration(nil), registry...)
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return &Runner{
+		db:         db,
+		collection: db.Collection("schema_migrations"),
+		migrations: migrations,
+	}
+}
+
+// Status reports applied and pending migrations. It returns ErrSchemaTooNew
+// alongside the status when the database is ahead of this build.
+func (r *Runner) Status(ctx context.Context) (*Status, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var applied []AppliedMigration
+	if err = cursor.All(ctx, &applied); err != nil {
+		return nil, err
+	}
+	sort.Slice(applied, func(i, j int) bool {
+		return applied[i].Version < applied[j].Version
+	})
+
+	status := &Status{Applied: applied, Pending: []int{}}
+	done := make(map[int]bool, len(applied))
+	for _, m := range applied {
+		done[m.Version] = true
+		if m.Version > status.Current {
+			status.Current = m.Version
+		}
+	}
+	for _, m := range r.migrations {
+		status.Latest = m.Version
+		if !done[m.Version] {
+			status.Pending = append(status.Pending, m.Version)
+		}
+	}
+
+	if status.Current > status.Latest {
+		return status, fmt.Errorf("%w: database is at version %d, build knows up to %d",
+			ErrSchemaTooNew, status.Current, status.Latest)
+	}
+	return status, nil
+}
+
+// Up applies every pending migration in version order and records each one
+// in schema_migrations once it succeeds.
+func (r *Runner) Up(ctx context.Context) (*Status, error) {
+	status, err := r.Status(ctx)
+	if err != nil {
+		return status, err
+	}
+
+	pending := make(map[int]bool, len(status.Pending))
+	for _, version := range status.Pending {
+		pending[version] = true
+	}
+
+	for _, m := range r.migrations {
+		if !pending[m.Version] {
+			continue
+		}
+
+		slog.Info("Applying migration", "version", m.Version, "description", m.Description)
+		if err := m.Up(ctx, r.db); err != nil {
+			return status, fmt.Errorf("migration %d (%s): %w", m.Version, m.Description, err)
+		}
+
+		record := AppliedMigration{
+			Version:     m.Version,
+			Description: m.Description,
+			AppliedAt:   time.Now(),
+		}
+		// Another instance may have applied the same migration concurrently;
+		// migrations are idempotent so its record is as good as ours.
+		if _, err := r.collection.InsertOne(ctx, record); err != nil && !mongo.IsDuplicateKeyError(err) {
+			return status, err
+		}
+	}
+
+	return r.Status(ctx)
+}
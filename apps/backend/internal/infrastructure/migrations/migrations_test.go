@@ -0,0 +1,69 @@
+package migrations_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/internal/infrastructure/migrations"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TestUpReportsDuplicateTMDBIDs checks, against the MongoDB server named by
+// MONGO_TEST_URI, that the unique TMDB ID migration names the duplicates it
+// can't index instead of failing with a bare duplicate key error. It is
+// skipped when the variable isn't set.
+func TestUpReportsDuplicateTMDBIDs(t *testing.T) {
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Disconnect(context.Background()) })
+
+	db := client.Database(fmt.Sprintf("migrationtest_%d", time.Now().UnixNano()))
+	t.Cleanup(func() { db.Drop(context.Background()) })
+
+	if _, err := db.Collection("movies").InsertMany(ctx, []interface{}{
+		bson.M{"tmdbMovieId": 603, "title": "The Matrix"},
+		bson.M{"tmdbMovieId": 603, "title": "The Matrix"},
+		bson.M{"tmdbMovieId": 550, "title": "Fight Club"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := migrations.NewRunner(db).Up(ctx)
+	if err == nil {
+		t.Fatal("Up succeeded with duplicate TMDB IDs")
+	}
+	for _, want := range []string{"movies", "tmdbMovieId", "603 (2 documents)"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Up error %q doesn't mention %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "550") {
+		t.Errorf("Up error %q lists a TMDB ID that isn't duplicated", err)
+	}
+	if status != nil && status.Current != 0 {
+		t.Errorf("Up applied up to migration %d", status.Current)
+	}
+
+	if _, err := db.Collection("movies").DeleteOne(ctx, bson.M{"tmdbMovieId": 603}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrations.NewRunner(db).Up(ctx); err != nil {
+		t.Fatalf("Up after removing the duplicate: %v", err)
+	}
+}
@@ -0,0 +1,310 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"backend/internal/infrastructure/repository/textsearch"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// registry lists every migration this build knows about. Append new
+// migrations with the next version number; never edit or reorder shipped ones.
+var registry = []Migration{
+	{
+		Version:     1,
+		Description: "unique TMDB ID indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := createUniqueIndex(ctx, db.Collection("movies"), "tmdbMovieId"); err != nil {
+				return err
+			}
+			return createUniqueIndex(ctx, db.Collection("tv_shows"), "tmdbTvShowId")
+		},
+	},
+	{
+		Version:     2,
+		Description: "catalog genre, popularity and refresh indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for _, name := range []string{"movies", "tv_shows"} {
+				if err := createIndexes(ctx, db.Collection(name),
+					mongo.IndexModel{
+						Keys:    bson.D{{Key: "genres.id", Value: 1}},
+						Options: options.Index().SetName("genres_id"),
+					},
+					mongo.IndexModel{
+						Keys:    bson.D{{Key: "voteAverage", Value: -1}, {Key: "voteCount", Value: -1}},
+						Options: options.Index().SetName("popularity"),
+					},
+					mongo.IndexModel{
+						Keys:    bson.D{{Key: "updatedAt", Value: 1}, {Key: "_id", Value: 1}},
+						Options: options.Index().SetName("updatedAt_id"),
+					},
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     3,
+		Description: "movie and TV show document validators",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := setValidator(ctx, db, "movies", catalogSchema("tmdbMovieId", "title")); err != nil {
+				return err
+			}
+			return setValidator(ctx, db, "tv_shows", catalogSchema("tmdbTvShowId", "name"))
+		},
+	},
+	{
+		Version:     4,
+		Description: "weighted text indexes for catalog search",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := createIndexes(ctx, db.Collection("movies"), mongo.IndexModel{
+				Keys: bson.D{
+					{Key: "title", Value: "text"},
+					{Key: "tagline", Value: "text"},
+					{Key: "overview", Value: "text"},
+				},
+				Options: options.Index().
+					SetName("catalog_text").
+					SetWeights(bson.M{"title": 10, "tagline": 4, "overview": 2}),
+			}); err != nil {
+				return err
+			}
+			return createIndexes(ctx, db.Collection("tv_shows"), mongo.IndexModel{
+				Keys: bson.D{
+					{Key: "name", Value: "text"},
+					{Key: "overview", Value: "text"},
+				},
+				Options: options.Index().
+					SetName("catalog_text").
+					SetWeights(bson.M{"name": 10, "overview": 2}),
+			})
+		},
+	},
+	{
+		Version:     5,
+		Description: "catalog browse sort indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			collections := []struct{ name, releaseField string }{
+				{"movies", "releaseDate"},
+				{"tv_shows", "firstAirDate"},
+			}
+			for _, coll := range collections {
+				if err := createIndexes(ctx, db.Collection(coll.name),
+					mongo.IndexModel{
+						Keys:    bson.D{{Key: coll.releaseField, Value: -1}, {Key: "_id", Value: 1}},
+						Options: options.Index().SetName("release"),
+					},
+					mongo.IndexModel{
+						Keys:    bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: 1}},
+						Options: options.Index().SetName("recent"),
+					},
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     6,
+		Description: "unique catalog override key",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createIndexes(ctx, db.Collection("catalog_overrides"), mongo.IndexModel{
+				Keys:    bson.D{{Key: "mediaType", Value: 1}, {Key: "tmdbId", Value: 1}},
+				Options: options.Index().SetUnique(true).SetName("mediaType_tmdbId_unique"),
+			})
+		},
+	},
+	{
+		Version:     7,
+		Description: "soft delete purge indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for _, name := range []string{"movies", "tv_shows", "users"} {
+				if err := createIndexes(ctx, db.Collection(name), mongo.IndexModel{
+					Keys:    bson.D{{Key: "deletedAt", Value: 1}},
+					Options: options.Index().SetSparse(true).SetName("deletedAt"),
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     8,
+		Description: "folded title keys for the prefix search fallback",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			collections := []struct{ name, titleField, keyField string }{
+				{"movies", "title", "titleKey"},
+				{"tv_shows", "name", "nameKey"},
+			}
+			for _, coll := range collections {
+				if err := backfillFolded(ctx, db.Collection(coll.name), coll.titleField, coll.keyField); err != nil {
+					return err
+				}
+				if err := createIndexes(ctx, db.Collection(coll.name), mongo.IndexModel{
+					Keys:    bson.D{{Key: coll.keyField, Value: 1}},
+					Options: options.Index().SetName(coll.keyField),
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     9,
+		Description: "unique person TMDB ID index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createUniqueIndex(ctx, db.Collection("people"), "tmdbPersonId")
+		},
+	},
+}
+
+func createIndexes(ctx context.Context, collection *mongo.Collection, models ...mongo.IndexModel) error {
+	_, err := collection.Indexes().CreateMany(ctx, models)
+	return err
+}
+
+// maxReportedDuplicates caps how many duplicate values a failed unique index
+// migration lists.
+const maxReportedDuplicates = 20
+
+// createUniqueIndex creates the unique index field_unique. Documents sharing
+// a value would make the build fail with a bare duplicate key error, so they
+// are looked for first and reported with what to do about them; they are not
+// merged automatically as other collections may reference either copy.
+func createUniqueIndex(ctx context.Context, collection *mongo.Collection, field string) error {
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$" + field}, {Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}}},
+		{{Key: "$match", Value: bson.D{{Key: "count", Value: bson.D{{Key: "$gt", Value: 1}}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: maxReportedDuplicates}},
+	}, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return err
+	}
+	var duplicates []struct {
+		Value interface{} `bson:"_id"`
+		Count int         `bson:"count"`
+	}
+	if err := cursor.All(ctx, &duplicates); err != nil {
+		return err
+	}
+
+	if len(duplicates) > 0 {
+		values := make([]string, len(duplicates))
+		for i, d := range duplicates {
+			values[i] = fmt.Sprintf("%v (%d documents)", d.Value, d.Count)
+		}
+		more := ""
+		if len(duplicates) == maxReportedDuplicates {
+			more = ", and possibly more"
+		}
+		return fmt.Errorf("%s has documents sharing a %s: %s%s; keep one document per value, "+
+			"e.g. inspect them with db.%s.find({%s: <value>}) and delete the extra ones, then run the migration again",
+			collection.Name(), field, strings.Join(values, ", "), more, collection.Name(), field)
+	}
+
+	return createIndexes(ctx, collection, mongo.IndexModel{
+		Keys:    bson.D{{Key: field, Value: 1}},
+		Options: options.Index().SetUnique(true).SetName(field + "_unique"),
+	})
+}
+
+// backfillFolded sets keyField to the folded value of titleField on every
+// document, in batches.
+func backfillFolded(ctx context.Context, collection *mongo.Collection, titleField, keyField string) error {
+	opts := options.Find().SetProjection(bson.M{titleField: 1})
+	cursor, err := collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	const batchSize = 500
+	writes := make([]mongo.WriteModel, 0, batchSize)
+	flush := func() error {
+		if len(writes) == 0 {
+			return nil
+		}
+		_, err := collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+		writes = writes[:0]
+		return err
+	}
+	for cursor.Next(ctx) {
+		id := cursor.Current.Lookup("_id")
+		title, _ := cursor.Current.Lookup(titleField).StringValueOK()
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": id}).
+			SetUpdate(bson.M{"$set": bson.M{keyField: textsearch.Fold(title)}}))
+		if len(writes) == batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// setValidator attaches a $jsonSchema validator to a collection, creating
+// the collection first when it doesn't exist yet. Validation is "moderate" so
+// legacy documents that predate the schema can still be updated.
+func setValidator(ctx context.Context, db *mongo.Database, collection string, schema bson.M) error {
+	validator := bson.M{"$jsonSchema": schema}
+
+	names, err := db.ListCollectionNames(ctx, bson.M{"name": collection})
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		opts := options.CreateCollection().
+			SetValidator(validator).
+			SetValidationLevel("moderate")
+		return db.CreateCollection(ctx, collection, opts)
+	}
+
+	return db.RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: collection},
+		{Key: "validator", Value: validator},
+		{Key: "validationLevel", Value: "moderate"},
+	}).Err()
+}
+
+// catalogSchema is the $jsonSchema shared by movies and TV shows, which
+// differ only in the name of their TMDB ID and title fields.
+func catalogSchema(tmdbIDField, titleField string) bson.M {
+	return bson.M{
+		"bsonType": "object",
+		"required": bson.A{tmdbIDField, titleField, "createdAt", "updatedAt"},
+		"properties": bson.M{
+			tmdbIDField: bson.M{"bsonType": bson.A{"int", "long"}, "minimum": 1},
+			titleField:  bson.M{"bsonType": "string"},
+			"voteAverage": bson.M{
+				"bsonType": bson.A{"double", "int", "long"},
+				"minimum":  0,
+				"maximum":  10,
+			},
+			"voteCount": bson.M{"bsonType": bson.A{"int", "long"}, "minimum": 0},
+			"genres": bson.M{
+				"bsonType": bson.A{"array", "null"},
+				"items": bson.M{
+					"bsonType": "object",
+					"required": bson.A{"id", "name"},
+				},
+			},
+			"createdAt": bson.M{"bsonType": "date"},
+			"updatedAt": bson.M{"bsonType": "date"},
+		},
+	}
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// visible matches titles an operator hasn't hidden.
+var visible = bson.M{"$ne": true}
+
+func catalogFilter(query domain.CatalogQuery) bson.M {
+	filter := bson.M{"hidden": visible, "deletedAt": notDeleted}
+	if query.GenreID != 0 {
+		filter["genres.id"] = query.GenreID
+	}
+	return filter
+}
+
+// catalogSort orders a catalog listing. releaseField is the release date
+// field of the collection ("releaseDate" or "firstAirDate"). Ties are broken
+// on _id so every document has a stable position for keyset paging.
+func catalogSort(sort domain.CatalogSort, releaseField string) bson.D {
+	var keys bson.D
+	switch sort {
+	case domain.SortByVote:
+		keys = bson.D{{Key: "voteAverage", Value: -1}, {Key: "voteCount", Value: -1}}
+	case domain.SortByReleaseDate:
+		keys = bson.D{{Key: releaseField, Value: -1}}
+	case domain.SortByRecent:
+		keys = bson.D{{Key: "createdAt", Value: -1}}
+	}
+	return append(keys, bson.E{Key: "_id", Value: 1})
+}
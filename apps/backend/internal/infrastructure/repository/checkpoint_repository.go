@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type checkpointRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCheckpointRepository(db *mongo.Database) domain.CheckpointRepository {
+	return &checkpointRepository{
+		collection: db.Collection("sync_checkpoints"),
+	}
+}
+
+func (r *checkpointRepository) Get(ctx context.Context, name string) (*domain.Checkpoint, error) {
+	var checkpoint domain.Checkpoint
+	err := r.collection.FindOne(ctx, bson.M{"_id": name}).Decode(&checkpoint)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &checkpoint, nil
+}
+
+func (r *checkpointRepository) Save(ctx context.Context, checkpoint *domain.Checkpoint) error {
+	checkpoint.UpdatedAt = time.Now()
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": checkpoint.Name}, checkpoint, opts)
+	return err
+}
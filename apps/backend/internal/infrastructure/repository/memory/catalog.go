@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func hasGenre(genres []domain.Genre, genreID int) bool {
+	for _, genre := range genres {
+		if genre.ID == genreID {
+			return true
+		}
+	}
+	return false
+}
+
+// isAfter reports whether (updatedAt, id) sorts after the refresh position.
+// The zero position is before everything.
+func isAfter(updatedAt time.Time, id primitive.ObjectID, after domain.RefreshPosition) bool {
+	if after.ID.IsZero() {
+		return true
+	}
+	return positionLess(after.UpdatedAt, after.ID, updatedAt, id)
+}
+
+// positionLess orders refresh positions by (updatedAt, id).
+func positionLess(aAt time.Time, aID primitive.ObjectID, bAt time.Time, bID primitive.ObjectID) bool {
+	if !aAt.Equal(bAt) {
+		return aAt.Before(bAt)
+	}
+	return idKey(aID) < idKey(bID)
+}
+
+// searchTotal returns the match count of a search when it was asked for.
+func searchTotal(matches int, withTotal bool) *int64 {
+	if !withTotal {
+		return nil
+	}
+	total := int64(matches)
+	return &total
+}
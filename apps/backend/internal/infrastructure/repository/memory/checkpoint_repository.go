@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend/internal/domain"
+)
+
+type checkpointRepository struct {
+	mu          sync.RWMutex
+	checkpoints map[string]domain.Checkpoint
+}
+
+func NewCheckpointRepository() domain.CheckpointRepository {
+	return &checkpointRepository{
+		checkpoints: make(map[string]domain.Checkpoint),
+	}
+}
+
+func (r *checkpointRepository) Get(ctx context.Context, name string) (*domain.Checkpoint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	checkpoint, ok := r.checkpoints[name]
+	if !ok {
+		return nil, nil
+	}
+	return &checkpoint, nil
+}
+
+func (r *checkpointRepository) Save(ctx context.Context, checkpoint *domain.Checkpoint) error {
+	checkpoint.UpdatedAt = time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checkpoints[checkpoint.Name] = *checkpoint
+	return nil
+}
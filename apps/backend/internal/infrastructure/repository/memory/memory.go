@@ -0,0 +1,132 @@
+// Package memory implements the domain repositories in process memory. It
+// mirrors the MongoDB repositories, including soft deletes, versioned
+// updates and keyset cursors, so use cases can run against it in tests and
+// the server can run without a database during local development. Nothing is
+// persisted across restarts.
+package memory
+
+import (
+	"errors"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// errDuplicateKey mirrors a unique index violation.
+var errDuplicateKey = errors.New("duplicate key")
+
+// New returns a fresh, empty set of in-memory repositories.
+func New() domain.Repositories {
+	return domain.Repositories{
+		Movies:      NewMovieRepository(),
+		TVShows:     NewTVShowRepository(),
+		People:      NewPersonRepository(),
+		Users:       NewUserRepository(),
+		Watchlists:  NewWatchlistRepository(),
+		Ratings:     NewRatingRepository(),
+		Overrides:   NewOverrideRepository(),
+		Checkpoints: NewCheckpointRepository(),
+	}
+}
+
+// Stored records are copied on the way in and out so callers can never
+// change them behind the repository's back, just as with a database.
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+func cloneGenres(genres []domain.Genre) []domain.Genre {
+	if genres == nil {
+		return nil
+	}
+	return append([]domain.Genre{}, genres...)
+}
+
+func cloneMovie(m *domain.Movie) *domain.Movie {
+	c := *m
+	c.Genres = cloneGenres(m.Genres)
+	c.DeletedAt = cloneTime(m.DeletedAt)
+	return &c
+}
+
+func cloneTVShow(t *domain.TVShow) *domain.TVShow {
+	c := *t
+	c.Genres = cloneGenres(t.Genres)
+	c.DeletedAt = cloneTime(t.DeletedAt)
+	return &c
+}
+
+func cloneUser(u *domain.User) *domain.User {
+	c := *u
+	c.DeletedAt = cloneTime(u.DeletedAt)
+	return &c
+}
+
+func cloneObjectID(id *primitive.ObjectID) *primitive.ObjectID {
+	if id == nil {
+		return nil
+	}
+	c := *id
+	return &c
+}
+
+func cloneWatchlist(w *domain.Watchlist) *domain.Watchlist {
+	c := *w
+	c.MovieID = cloneObjectID(w.MovieID)
+	c.TVShowID = cloneObjectID(w.TVShowID)
+	return &c
+}
+
+func cloneRating(r *domain.Rating) *domain.Rating {
+	c := *r
+	c.MovieID = cloneObjectID(r.MovieID)
+	c.TVShowID = cloneObjectID(r.TVShowID)
+	return &c
+}
+
+func cloneString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	return &c
+}
+
+func cloneOverride(o *domain.CatalogOverride) *domain.CatalogOverride {
+	c := *o
+	f := o.Fields
+	c.Fields = domain.OverrideFields{
+		Title:        cloneString(f.Title),
+		Overview:     cloneString(f.Overview),
+		Tagline:      cloneString(f.Tagline),
+		PosterPath:   cloneString(f.PosterPath),
+		BackdropPath: cloneString(f.BackdropPath),
+		ReleaseDate:  cloneString(f.ReleaseDate),
+		Status:       cloneString(f.Status),
+	}
+	if f.Genres != nil {
+		genres := cloneGenres(*f.Genres)
+		c.Fields.Genres = &genres
+	}
+	return &c
+}
+
+// objectIDs parses hex IDs, failing on the first malformed one.
+func objectIDs(ids []string) (map[primitive.ObjectID]bool, error) {
+	parsed := make(map[primitive.ObjectID]bool, len(ids))
+	for _, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, err
+		}
+		parsed[objectID] = true
+	}
+	return parsed, nil
+}
@@ -0,0 +1,13 @@
+package memory_test
+
+import (
+	"testing"
+
+	"backend/internal/domain"
+	"backend/internal/infrastructure/repository/memory"
+	"backend/internal/infrastructure/repository/repotest"
+)
+
+func TestRepositories(t *testing.T) {
+	repotest.Run(t, func(*testing.T) domain.Repositories { return memory.New() })
+}
@@ -0,0 +1,366 @@
+package memory
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"backend/internal/domain"
+	"backend/internal/infrastructure/repository/textsearch"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type movieRepository struct {
+	mu     sync.RWMutex
+	movies map[primitive.ObjectID]*domain.Movie
+}
+
+func NewMovieRepository() domain.MovieRepository {
+	return &movieRepository{
+		movies: make(map[primitive.ObjectID]*domain.Movie),
+	}
+}
+
+func (r *movieRepository) GetByID(ctx context.Context, id string) (*domain.Movie, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	movie, ok := r.movies[objectID]
+	if !ok || movie.DeletedAt != nil {
+		return nil, domain.ErrNotFound
+	}
+	return cloneMovie(movie), nil
+}
+
+func (r *movieRepository) GetByTMDBID(ctx context.Context, tmdbID int) (*domain.Movie, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	movie := r.byTMDBID(tmdbID)
+	if movie == nil || movie.DeletedAt != nil {
+		return nil, domain.ErrNotFound
+	}
+	return cloneMovie(movie), nil
+}
+
+func (r *movieRepository) GetByTMDBIDs(ctx context.Context, tmdbIDs []int) ([]*domain.Movie, error) {
+	wanted := make(map[int]bool, len(tmdbIDs))
+	for _, tmdbID := range tmdbIDs {
+		wanted[tmdbID] = true
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var movies []*domain.Movie
+	for _, movie := range r.movies {
+		if wanted[movie.TMDBMovieID] && movie.DeletedAt == nil {
+			movies = append(movies, cloneMovie(movie))
+		}
+	}
+	return movies, nil
+}
+
+func (r *movieRepository) GetTMDBIDs(ctx context.Context, ids []string) (map[string]int, error) {
+	wanted, err := objectIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tmdbIDs := make(map[string]int, len(wanted))
+	for id := range wanted {
+		if stored, ok := r.movies[id]; ok {
+			tmdbIDs[id.Hex()] = stored.TMDBMovieID
+		}
+	}
+	return tmdbIDs, nil
+}
+
+func (r *movieRepository) Create(ctx context.Context, movie *domain.Movie) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.byTMDBID(movie.TMDBMovieID) != nil {
+		return errDuplicateKey
+	}
+
+	movie.ID = primitive.NewObjectID()
+	movie.CreatedAt = time.Now()
+	movie.UpdatedAt = time.Now()
+	movie.Version = 1
+
+	r.movies[movie.ID] = cloneMovie(movie)
+	return nil
+}
+
+// Upsert stores a movie fetched in full from TMDB, keyed on its TMDB ID.
+func (r *movieRepository) Upsert(ctx context.Context, movie *domain.Movie) error {
+	return r.upsert([]*domain.Movie{movie}, (*domain.Movie).ApplyUpstream)
+}
+
+// UpsertMany stores movies from a TMDB list result. Stored movies only get
+// the fields list results carry refreshed; new ones are inserted whole.
+func (r *movieRepository) UpsertMany(ctx context.Context, movies []*domain.Movie) error {
+	return r.upsert(movies, (*domain.Movie).ApplyListing)
+}
+
+// upsert inserts the movies not stored yet and refreshes the stored ones with
+// apply, leaving those it doesn't change at their version. The stored ID,
+// CreatedAt, UpdatedAt, Hidden flag, DeletedAt and Version are copied back
+// onto each movie. Soft-deleted movies stay deleted.
+func (r *movieRepository) upsert(movies []*domain.Movie, apply func(stored, upstream *domain.Movie)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, movie := range movies {
+		stored := r.byTMDBID(movie.TMDBMovieID)
+		if stored == nil {
+			stored = cloneMovie(movie)
+			stored.ID = primitive.NewObjectID()
+			stored.CreatedAt = now
+			stored.UpdatedAt = now
+			stored.Version = 1
+			stored.Hidden = false
+			stored.DeletedAt = nil
+			r.movies[stored.ID] = stored
+		} else {
+			updated := cloneMovie(stored)
+			apply(updated, cloneMovie(movie))
+			if !reflect.DeepEqual(updated, stored) {
+				updated.UpdatedAt = now
+				updated.Version++
+				r.movies[updated.ID] = updated
+				stored = updated
+			}
+		}
+
+		movie.ID = stored.ID
+		movie.CreatedAt = stored.CreatedAt
+		movie.UpdatedAt = stored.UpdatedAt
+		movie.Hidden = stored.Hidden
+		movie.DeletedAt = cloneTime(stored.DeletedAt)
+		movie.Version = stored.Version
+	}
+
+	return nil
+}
+
+func (r *movieRepository) Update(ctx context.Context, movie *domain.Movie) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.movies[movie.ID]
+	if !ok || stored.DeletedAt != nil {
+		return domain.ErrNotFound
+	}
+	if stored.Version != movie.Version {
+		return domain.ErrVersionConflict
+	}
+
+	movie.UpdatedAt = time.Now()
+	movie.Version++
+	r.movies[movie.ID] = cloneMovie(movie)
+	return nil
+}
+
+// Delete soft-deletes a movie; it stays stored until purged.
+func (r *movieRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	movie, ok := r.movies[objectID]
+	if !ok || movie.DeletedAt != nil {
+		return domain.ErrNotFound
+	}
+	now := time.Now()
+	movie.DeletedAt = &now
+	movie.Version++
+	return nil
+}
+
+func (r *movieRepository) Restore(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	movie, ok := r.movies[objectID]
+	if !ok || movie.DeletedAt == nil {
+		return domain.ErrNotFound
+	}
+	movie.DeletedAt = nil
+	movie.UpdatedAt = time.Now()
+	movie.Version++
+	return nil
+}
+
+func (r *movieRepository) ListDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := []string{}
+	for id, movie := range r.movies {
+		if movie.DeletedAt != nil && movie.DeletedAt.Before(deletedBefore) {
+			ids = append(ids, id.Hex())
+		}
+	}
+	return ids, nil
+}
+
+func (r *movieRepository) Purge(ctx context.Context, ids []string, deletedBefore time.Time) ([]string, error) {
+	purge, err := objectIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := make([]string, 0, len(purge))
+	for id := range purge {
+		if movie, ok := r.movies[id]; ok {
+			if movie.DeletedAt == nil || !movie.DeletedAt.Before(deletedBefore) {
+				continue
+			}
+			delete(r.movies, id)
+		}
+		purged = append(purged, id.Hex())
+	}
+	return purged, nil
+}
+
+func (r *movieRepository) List(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.Movie], error) {
+	return r.Browse(ctx, domain.CatalogQuery{}, page)
+}
+
+// Search ranks movies by relevance like the weighted Mongo text index. When
+// nothing matches, it falls back to a title prefix match, unscored. Input
+// made only of search operators matches nothing.
+func (r *movieRepository) Search(ctx context.Context, query string, limit, offset int, withTotal bool) ([]*domain.MovieSearchResult, *int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	terms := textsearch.Terms(query)
+	if len(terms) == 0 {
+		return []*domain.MovieSearchResult{}, searchTotal(0, withTotal), nil
+	}
+	var matches []textsearch.Match[*domain.Movie]
+	for _, movie := range r.visible() {
+		score := textsearch.Score(terms,
+			textsearch.Field{Text: movie.Title, Weight: 10},
+			textsearch.Field{Text: movie.Tagline, Weight: 4},
+			textsearch.Field{Text: movie.Overview, Weight: 2},
+		)
+		if score > 0 {
+			matches = append(matches, textsearch.Match[*domain.Movie]{Item: movie, Score: score})
+		}
+	}
+	textsearch.Rank(matches, func(m *domain.Movie) string { return idKey(m.ID) })
+
+	if len(matches) == 0 {
+		for _, movie := range r.visible() {
+			if textsearch.HasPrefixFold(movie.Title, query) {
+				matches = append(matches, textsearch.Match[*domain.Movie]{Item: movie})
+			}
+		}
+		sort.SliceStable(matches, func(i, j int) bool {
+			return matches[i].Item.Title < matches[j].Item.Title
+		})
+	}
+
+	results := []*domain.MovieSearchResult{}
+	for _, match := range textsearch.Window(matches, limit, offset) {
+		results = append(results, &domain.MovieSearchResult{Movie: *cloneMovie(match.Item), Score: match.Score})
+	}
+	return results, searchTotal(len(matches), withTotal), nil
+}
+
+func (r *movieRepository) GetByGenre(ctx context.Context, genreID int, page domain.PageRequest) (*domain.Page[*domain.Movie], error) {
+	return r.Browse(ctx, domain.CatalogQuery{GenreID: genreID}, page)
+}
+
+func (r *movieRepository) GetPopular(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.Movie], error) {
+	return r.Browse(ctx, domain.CatalogQuery{Sort: domain.SortByVote}, page)
+}
+
+// Browse lists one page of stored movies matching the query.
+func (r *movieRepository) Browse(ctx context.Context, query domain.CatalogQuery, page domain.PageRequest) (*domain.Page[*domain.Movie], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var movies []*domain.Movie
+	for _, movie := range r.visible() {
+		if query.GenreID == 0 || hasGenre(movie.Genres, query.GenreID) {
+			movies = append(movies, cloneMovie(movie))
+		}
+	}
+
+	return paginate(movies, catalogListing(query.Sort, func(m *domain.Movie) catalogFields {
+		return catalogFields{
+			id:        m.ID,
+			vote:      m.VoteAverage,
+			voteCount: m.VoteCount,
+			release:   m.ReleaseDate,
+			createdAt: m.CreatedAt,
+		}
+	}), page)
+}
+
+func (r *movieRepository) ListStale(ctx context.Context, updatedBefore time.Time, after domain.RefreshPosition, limit int) ([]*domain.Movie, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var movies []*domain.Movie
+	for _, movie := range r.movies {
+		if movie.DeletedAt == nil && movie.UpdatedAt.Before(updatedBefore) && isAfter(movie.UpdatedAt, movie.ID, after) {
+			movies = append(movies, cloneMovie(movie))
+		}
+	}
+	sort.Slice(movies, func(i, j int) bool {
+		return positionLess(movies[i].UpdatedAt, movies[i].ID, movies[j].UpdatedAt, movies[j].ID)
+	})
+
+	return textsearch.Window(movies, limit, 0), nil
+}
+
+// byTMDBID finds a stored movie, deleted or not. Callers hold the lock.
+func (r *movieRepository) byTMDBID(tmdbID int) *domain.Movie {
+	for _, movie := range r.movies {
+		if movie.TMDBMovieID == tmdbID {
+			return movie
+		}
+	}
+	return nil
+}
+
+// visible lists stored movies that are neither hidden nor deleted. Callers
+// hold the lock.
+func (r *movieRepository) visible() []*domain.Movie {
+	var movies []*domain.Movie
+	for _, movie := range r.movies {
+		if !movie.Hidden && movie.DeletedAt == nil {
+			movies = append(movies, movie)
+		}
+	}
+	return movies
+}
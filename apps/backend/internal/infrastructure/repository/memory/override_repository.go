@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type overrideKey struct {
+	mediaType string
+	tmdbID    int
+}
+
+type overrideRepository struct {
+	mu        sync.RWMutex
+	overrides map[overrideKey]*domain.CatalogOverride
+}
+
+func NewOverrideRepository() domain.OverrideRepository {
+	return &overrideRepository{
+		overrides: make(map[overrideKey]*domain.CatalogOverride),
+	}
+}
+
+func (r *overrideRepository) Get(ctx context.Context, mediaType string, tmdbID int) (*domain.CatalogOverride, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	override, ok := r.overrides[overrideKey{mediaType, tmdbID}]
+	if !ok {
+		return nil, nil
+	}
+	return cloneOverride(override), nil
+}
+
+func (r *overrideRepository) GetMany(ctx context.Context, mediaType string, tmdbIDs []int) ([]*domain.CatalogOverride, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var overrides []*domain.CatalogOverride
+	for _, tmdbID := range tmdbIDs {
+		if override, ok := r.overrides[overrideKey{mediaType, tmdbID}]; ok {
+			overrides = append(overrides, cloneOverride(override))
+		}
+	}
+	return overrides, nil
+}
+
+// Save replaces the stored override for the same media type and TMDB ID,
+// creating it when missing.
+func (r *overrideRepository) Save(ctx context.Context, override *domain.CatalogOverride) error {
+	override.UpdatedAt = time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := overrideKey{override.MediaType, override.TMDBID}
+	if stored, ok := r.overrides[key]; ok {
+		override.ID = stored.ID
+	} else {
+		override.ID = primitive.NewObjectID()
+	}
+	r.overrides[key] = cloneOverride(override)
+	return nil
+}
+
+func (r *overrideRepository) Delete(ctx context.Context, mediaType string, tmdbID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.overrides, overrideKey{mediaType, tmdbID})
+	return nil
+}
+
+func (r *overrideRepository) DeleteMany(ctx context.Context, mediaType string, tmdbIDs []int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, tmdbID := range tmdbIDs {
+		delete(r.overrides, overrideKey{mediaType, tmdbID})
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package memory
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const defaultPageLimit = 20
+
+// sortKey is the position of an item in a listing. Values are float64 or
+// string so they survive the JSON round trip through a cursor unchanged.
+type sortKey []interface{}
+
+// listing describes one sort order. Like the Mongo sorts, the last key must
+// be unique (normally the ID) so every item has a distinct position.
+type listing[T any] struct {
+	// name identifies the sort; cursors issued for another one are rejected.
+	name string
+	// directions holds 1 (ascending) or -1 (descending) per key.
+	directions []int
+	key        func(T) sortKey
+}
+
+type cursorPayload struct {
+	Listing string        `json:"l"`
+	Key     []interface{} `json:"k"`
+}
+
+// paginate returns one page of items in listing order, starting after the
+// cursor position. items is sorted in place.
+func paginate[T any](items []T, l listing[T], page domain.PageRequest) (*domain.Page[T], error) {
+	if page.Limit <= 0 {
+		page.Limit = defaultPageLimit
+	}
+
+	total := int64(len(items))
+	sort.SliceStable(items, func(i, j int) bool {
+		return l.compare(l.key(items[i]), l.key(items[j])) < 0
+	})
+
+	if page.Cursor != "" {
+		after, err := l.decodeCursor(page.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		start := sort.Search(len(items), func(i int) bool {
+			return l.compare(l.key(items[i]), after) > 0
+		})
+		items = items[start:]
+	}
+
+	result := &domain.Page[T]{Items: items}
+	if len(items) > page.Limit {
+		result.Items = items[:page.Limit]
+		result.NextCursor = l.encodeCursor(l.key(result.Items[page.Limit-1]))
+	}
+	if page.WithTotal {
+		result.Total = &total
+	}
+
+	return result, nil
+}
+
+func (l listing[T]) compare(a, b sortKey) int {
+	for i, direction := range l.directions {
+		if c := compareValues(a[i], b[i]); c != 0 {
+			return c * direction
+		}
+	}
+	return 0
+}
+
+func compareValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case float64:
+		b, _ := b.(float64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case string:
+		b, _ := b.(string)
+		return strings.Compare(a, b)
+	}
+	return 0
+}
+
+func (l listing[T]) encodeCursor(key sortKey) string {
+	encoded, _ := json.Marshal(cursorPayload{Listing: l.name, Key: key})
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// decodeCursor unpacks a cursor and checks it was issued for this listing.
+func (l listing[T]) decodeCursor(cursor string) (sortKey, error) {
+	encoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(encoded, &payload); err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	if payload.Listing != l.name || len(payload.Key) != len(l.directions) {
+		return nil, domain.ErrInvalidCursor
+	}
+	for _, value := range payload.Key {
+		switch value.(type) {
+		case float64, string:
+		default:
+			return nil, domain.ErrInvalidCursor
+		}
+	}
+
+	return payload.Key, nil
+}
+
+// timeKey formats t with a fixed width so times order as strings.
+func timeKey(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000000000Z")
+}
+
+// idKey formats an ObjectID so IDs order as strings the way MongoDB orders
+// them.
+func idKey(id primitive.ObjectID) string {
+	return id.Hex()
+}
+
+// catalogFields are the values catalog sorts look at.
+type catalogFields struct {
+	id        primitive.ObjectID
+	vote      float64
+	voteCount int
+	release   string
+	createdAt time.Time
+}
+
+// catalogListing mirrors the Mongo catalogSort orders.
+func catalogListing[T any](s domain.CatalogSort, fields func(T) catalogFields) listing[T] {
+	switch s {
+	case domain.SortByVote:
+		return listing[T]{
+			name:       "vote",
+			directions: []int{-1, -1, 1},
+			key: func(item T) sortKey {
+				f := fields(item)
+				return sortKey{f.vote, float64(f.voteCount), idKey(f.id)}
+			},
+		}
+	case domain.SortByReleaseDate:
+		return listing[T]{
+			name:       "release_date",
+			directions: []int{-1, 1},
+			key: func(item T) sortKey {
+				f := fields(item)
+				return sortKey{f.release, idKey(f.id)}
+			},
+		}
+	case domain.SortByRecent:
+		return listing[T]{
+			name:       "recent",
+			directions: []int{-1, 1},
+			key: func(item T) sortKey {
+				f := fields(item)
+				return sortKey{timeKey(f.createdAt), idKey(f.id)}
+			},
+		}
+	}
+	return listing[T]{
+		name:       "id",
+		directions: []int{1},
+		key: func(item T) sortKey {
+			return sortKey{idKey(fields(item).id)}
+		},
+	}
+}
+
+// newestFirst orders ratings and watchlist entries by a timestamp, newest
+// first.
+func newestFirst[T any](at func(T) time.Time, id func(T) primitive.ObjectID) listing[T] {
+	return listing[T]{
+		name:       "newest",
+		directions: []int{-1, 1},
+		key: func(item T) sortKey {
+			return sortKey{timeKey(at(item)), idKey(id(item))}
+		},
+	}
+}
+
+// byID orders items by ID.
+func byID[T any](id func(T) primitive.ObjectID) listing[T] {
+	return listing[T]{
+		name:       "id",
+		directions: []int{1},
+		key: func(item T) sortKey {
+			return sortKey{idKey(id(item))}
+		},
+	}
+}
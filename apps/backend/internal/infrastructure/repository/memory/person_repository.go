@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type personRepository struct {
+	mu     sync.RWMutex
+	people map[int]domain.Person
+}
+
+func NewPersonRepository() domain.PersonRepository {
+	return &personRepository{
+		people: make(map[int]domain.Person),
+	}
+}
+
+func (r *personRepository) GetByTMDBID(ctx context.Context, tmdbID int) (*domain.Person, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	person, ok := r.people[tmdbID]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &person, nil
+}
+
+func (r *personRepository) GetByTMDBIDs(ctx context.Context, tmdbIDs []int) ([]*domain.Person, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var people []*domain.Person
+	for _, tmdbID := range tmdbIDs {
+		if person, ok := r.people[tmdbID]; ok {
+			people = append(people, &person)
+		}
+	}
+	return people, nil
+}
+
+func (r *personRepository) Upsert(ctx context.Context, person *domain.Person) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.people[person.TMDBPersonID]
+	if !ok {
+		now := time.Now()
+		person.ID = primitive.NewObjectID()
+		person.CreatedAt = now
+		person.UpdatedAt = now
+		person.Version = 1
+		r.people[person.TMDBPersonID] = *person
+		return nil
+	}
+
+	person.ID = stored.ID
+	person.CreatedAt = stored.CreatedAt
+	person.UpdatedAt = stored.UpdatedAt
+	person.Version = stored.Version
+	if *person == stored {
+		return nil
+	}
+
+	person.UpdatedAt = time.Now()
+	person.Version++
+	r.people[person.TMDBPersonID] = *person
+	return nil
+}
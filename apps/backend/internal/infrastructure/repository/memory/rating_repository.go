@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ratingRepository struct {
+	mu      sync.RWMutex
+	ratings map[primitive.ObjectID]*domain.Rating
+}
+
+func NewRatingRepository() domain.RatingRepository {
+	return &ratingRepository{
+		ratings: make(map[primitive.ObjectID]*domain.Rating),
+	}
+}
+
+func (r *ratingRepository) GetByUserAndItem(ctx context.Context, userID, itemID string, itemType string) (*domain.Rating, error) {
+	match, err := userItemMatcher(userID, itemID, itemType)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, rating := range r.ratings {
+		if match(rating.UserID, rating.Type, rating.MovieID, rating.TVShowID) {
+			return cloneRating(rating), nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+// GetByItem lists the ratings of one movie or TV show, newest first.
+func (r *ratingRepository) GetByItem(ctx context.Context, itemID string, itemType string, page domain.PageRequest) (*domain.Page[*domain.Rating], error) {
+	items, err := objectIDs([]string{itemID})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.page(func(rating *domain.Rating) bool {
+		return refersToAny(items, itemType, rating.Type, rating.MovieID, rating.TVShowID)
+	}, page)
+}
+
+// GetByUser lists a user's ratings, newest first.
+func (r *ratingRepository) GetByUser(ctx context.Context, userID string, page domain.PageRequest) (*domain.Page[*domain.Rating], error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.page(func(rating *domain.Rating) bool {
+		return rating.UserID == userObjectID
+	}, page)
+}
+
+func (r *ratingRepository) Create(ctx context.Context, rating *domain.Rating) error {
+	rating.ID = primitive.NewObjectID()
+	rating.CreatedAt = time.Now()
+	rating.UpdatedAt = time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ratings[rating.ID] = cloneRating(rating)
+	return nil
+}
+
+func (r *ratingRepository) Update(ctx context.Context, rating *domain.Rating) error {
+	rating.UpdatedAt = time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.ratings[rating.ID]; ok {
+		r.ratings[rating.ID] = cloneRating(rating)
+	}
+	return nil
+}
+
+func (r *ratingRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.ratings, objectID)
+	return nil
+}
+
+// GetAverageRating returns the mean rating of an item and how many ratings
+// it is based on.
+func (r *ratingRepository) GetAverageRating(ctx context.Context, itemID string, itemType string) (float64, int64, error) {
+	items, err := objectIDs([]string{itemID})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var sum float64
+	var count int64
+	for _, rating := range r.ratings {
+		if refersToAny(items, itemType, rating.Type, rating.MovieID, rating.TVShowID) {
+			sum += rating.Rating
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, 0, nil
+	}
+	return sum / float64(count), count, nil
+}
+
+// DeleteByItems drops every rating of the given movies or TV shows.
+func (r *ratingRepository) DeleteByItems(ctx context.Context, itemIDs []string, itemType string) error {
+	items, err := objectIDs(itemIDs)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, rating := range r.ratings {
+		if refersToAny(items, itemType, rating.Type, rating.MovieID, rating.TVShowID) {
+			delete(r.ratings, id)
+		}
+	}
+	return nil
+}
+
+// DeleteByUsers drops every rating by the given users.
+func (r *ratingRepository) DeleteByUsers(ctx context.Context, userIDs []string) error {
+	users, err := objectIDs(userIDs)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, rating := range r.ratings {
+		if users[rating.UserID] {
+			delete(r.ratings, id)
+		}
+	}
+	return nil
+}
+
+// page lists the matching ratings, newest first.
+func (r *ratingRepository) page(match func(*domain.Rating) bool, page domain.PageRequest) (*domain.Page[*domain.Rating], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var ratings []*domain.Rating
+	for _, rating := range r.ratings {
+		if match(rating) {
+			ratings = append(ratings, cloneRating(rating))
+		}
+	}
+
+	return paginate(ratings, newestFirst(
+		func(r *domain.Rating) time.Time { return r.CreatedAt },
+		func(r *domain.Rating) primitive.ObjectID { return r.ID },
+	), page)
+}
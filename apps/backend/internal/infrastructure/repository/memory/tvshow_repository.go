@@ -0,0 +1,365 @@
+package memory
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"backend/internal/domain"
+	"backend/internal/infrastructure/repository/textsearch"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type tvShowRepository struct {
+	mu      sync.RWMutex
+	tvShows map[primitive.ObjectID]*domain.TVShow
+}
+
+func NewTVShowRepository() domain.TVShowRepository {
+	return &tvShowRepository{
+		tvShows: make(map[primitive.ObjectID]*domain.TVShow),
+	}
+}
+
+func (r *tvShowRepository) GetByID(ctx context.Context, id string) (*domain.TVShow, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tvShow, ok := r.tvShows[objectID]
+	if !ok || tvShow.DeletedAt != nil {
+		return nil, domain.ErrNotFound
+	}
+	return cloneTVShow(tvShow), nil
+}
+
+func (r *tvShowRepository) GetByTMDBID(ctx context.Context, tmdbID int) (*domain.TVShow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tvShow := r.byTMDBID(tmdbID)
+	if tvShow == nil || tvShow.DeletedAt != nil {
+		return nil, domain.ErrNotFound
+	}
+	return cloneTVShow(tvShow), nil
+}
+
+func (r *tvShowRepository) GetByTMDBIDs(ctx context.Context, tmdbIDs []int) ([]*domain.TVShow, error) {
+	wanted := make(map[int]bool, len(tmdbIDs))
+	for _, tmdbID := range tmdbIDs {
+		wanted[tmdbID] = true
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var tvShows []*domain.TVShow
+	for _, tvShow := range r.tvShows {
+		if wanted[tvShow.TMDBTVShowID] && tvShow.DeletedAt == nil {
+			tvShows = append(tvShows, cloneTVShow(tvShow))
+		}
+	}
+	return tvShows, nil
+}
+
+func (r *tvShowRepository) GetTMDBIDs(ctx context.Context, ids []string) (map[string]int, error) {
+	wanted, err := objectIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tmdbIDs := make(map[string]int, len(wanted))
+	for id := range wanted {
+		if stored, ok := r.tvShows[id]; ok {
+			tmdbIDs[id.Hex()] = stored.TMDBTVShowID
+		}
+	}
+	return tmdbIDs, nil
+}
+
+func (r *tvShowRepository) Create(ctx context.Context, tvShow *domain.TVShow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.byTMDBID(tvShow.TMDBTVShowID) != nil {
+		return errDuplicateKey
+	}
+
+	tvShow.ID = primitive.NewObjectID()
+	tvShow.CreatedAt = time.Now()
+	tvShow.UpdatedAt = time.Now()
+	tvShow.Version = 1
+
+	r.tvShows[tvShow.ID] = cloneTVShow(tvShow)
+	return nil
+}
+
+// Upsert stores a TV show fetched in full from TMDB, keyed on its TMDB ID.
+func (r *tvShowRepository) Upsert(ctx context.Context, tvShow *domain.TVShow) error {
+	return r.upsert([]*domain.TVShow{tvShow}, (*domain.TVShow).ApplyUpstream)
+}
+
+// UpsertMany stores TV shows from a TMDB list result. Stored shows only get
+// the fields list results carry refreshed; new ones are inserted whole.
+func (r *tvShowRepository) UpsertMany(ctx context.Context, tvShows []*domain.TVShow) error {
+	return r.upsert(tvShows, (*domain.TVShow).ApplyListing)
+}
+
+// upsert inserts the TV shows not stored yet and refreshes the stored ones with
+// apply, leaving those it doesn't change at their version. The stored ID,
+// CreatedAt, UpdatedAt, Hidden flag, DeletedAt and Version are copied back
+// onto each show. Soft-deleted TV shows stay deleted.
+func (r *tvShowRepository) upsert(tvShows []*domain.TVShow, apply func(stored, upstream *domain.TVShow)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, tvShow := range tvShows {
+		stored := r.byTMDBID(tvShow.TMDBTVShowID)
+		if stored == nil {
+			stored = cloneTVShow(tvShow)
+			stored.ID = primitive.NewObjectID()
+			stored.CreatedAt = now
+			stored.UpdatedAt = now
+			stored.Version = 1
+			stored.Hidden = false
+			stored.DeletedAt = nil
+			r.tvShows[stored.ID] = stored
+		} else {
+			updated := cloneTVShow(stored)
+			apply(updated, cloneTVShow(tvShow))
+			if !reflect.DeepEqual(updated, stored) {
+				updated.UpdatedAt = now
+				updated.Version++
+				r.tvShows[updated.ID] = updated
+				stored = updated
+			}
+		}
+
+		tvShow.ID = stored.ID
+		tvShow.CreatedAt = stored.CreatedAt
+		tvShow.UpdatedAt = stored.UpdatedAt
+		tvShow.Hidden = stored.Hidden
+		tvShow.DeletedAt = cloneTime(stored.DeletedAt)
+		tvShow.Version = stored.Version
+	}
+
+	return nil
+}
+
+func (r *tvShowRepository) Update(ctx context.Context, tvShow *domain.TVShow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tvShows[tvShow.ID]
+	if !ok || stored.DeletedAt != nil {
+		return domain.ErrNotFound
+	}
+	if stored.Version != tvShow.Version {
+		return domain.ErrVersionConflict
+	}
+
+	tvShow.UpdatedAt = time.Now()
+	tvShow.Version++
+	r.tvShows[tvShow.ID] = cloneTVShow(tvShow)
+	return nil
+}
+
+// Delete soft-deletes a TV show; it stays stored until purged.
+func (r *tvShowRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tvShow, ok := r.tvShows[objectID]
+	if !ok || tvShow.DeletedAt != nil {
+		return domain.ErrNotFound
+	}
+	now := time.Now()
+	tvShow.DeletedAt = &now
+	tvShow.Version++
+	return nil
+}
+
+func (r *tvShowRepository) Restore(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tvShow, ok := r.tvShows[objectID]
+	if !ok || tvShow.DeletedAt == nil {
+		return domain.ErrNotFound
+	}
+	tvShow.DeletedAt = nil
+	tvShow.UpdatedAt = time.Now()
+	tvShow.Version++
+	return nil
+}
+
+func (r *tvShowRepository) ListDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := []string{}
+	for id, tvShow := range r.tvShows {
+		if tvShow.DeletedAt != nil && tvShow.DeletedAt.Before(deletedBefore) {
+			ids = append(ids, id.Hex())
+		}
+	}
+	return ids, nil
+}
+
+func (r *tvShowRepository) Purge(ctx context.Context, ids []string, deletedBefore time.Time) ([]string, error) {
+	purge, err := objectIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := make([]string, 0, len(purge))
+	for id := range purge {
+		if tvShow, ok := r.tvShows[id]; ok {
+			if tvShow.DeletedAt == nil || !tvShow.DeletedAt.Before(deletedBefore) {
+				continue
+			}
+			delete(r.tvShows, id)
+		}
+		purged = append(purged, id.Hex())
+	}
+	return purged, nil
+}
+
+func (r *tvShowRepository) List(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.TVShow], error) {
+	return r.Browse(ctx, domain.CatalogQuery{}, page)
+}
+
+// Search ranks TV shows by relevance like the weighted Mongo text index. When
+// nothing matches, it falls back to a name prefix match, unscored. Input
+// made only of search operators matches nothing.
+func (r *tvShowRepository) Search(ctx context.Context, query string, limit, offset int, withTotal bool) ([]*domain.TVShowSearchResult, *int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	terms := textsearch.Terms(query)
+	if len(terms) == 0 {
+		return []*domain.TVShowSearchResult{}, searchTotal(0, withTotal), nil
+	}
+	var matches []textsearch.Match[*domain.TVShow]
+	for _, tvShow := range r.visible() {
+		score := textsearch.Score(terms,
+			textsearch.Field{Text: tvShow.Name, Weight: 10},
+			textsearch.Field{Text: tvShow.Overview, Weight: 2},
+		)
+		if score > 0 {
+			matches = append(matches, textsearch.Match[*domain.TVShow]{Item: tvShow, Score: score})
+		}
+	}
+	textsearch.Rank(matches, func(t *domain.TVShow) string { return idKey(t.ID) })
+
+	if len(matches) == 0 {
+		for _, tvShow := range r.visible() {
+			if textsearch.HasPrefixFold(tvShow.Name, query) {
+				matches = append(matches, textsearch.Match[*domain.TVShow]{Item: tvShow})
+			}
+		}
+		sort.SliceStable(matches, func(i, j int) bool {
+			return matches[i].Item.Name < matches[j].Item.Name
+		})
+	}
+
+	results := []*domain.TVShowSearchResult{}
+	for _, match := range textsearch.Window(matches, limit, offset) {
+		results = append(results, &domain.TVShowSearchResult{TVShow: *cloneTVShow(match.Item), Score: match.Score})
+	}
+	return results, searchTotal(len(matches), withTotal), nil
+}
+
+func (r *tvShowRepository) GetByGenre(ctx context.Context, genreID int, page domain.PageRequest) (*domain.Page[*domain.TVShow], error) {
+	return r.Browse(ctx, domain.CatalogQuery{GenreID: genreID}, page)
+}
+
+func (r *tvShowRepository) GetPopular(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.TVShow], error) {
+	return r.Browse(ctx, domain.CatalogQuery{Sort: domain.SortByVote}, page)
+}
+
+// Browse lists one page of stored TV shows matching the query.
+func (r *tvShowRepository) Browse(ctx context.Context, query domain.CatalogQuery, page domain.PageRequest) (*domain.Page[*domain.TVShow], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var tvShows []*domain.TVShow
+	for _, tvShow := range r.visible() {
+		if query.GenreID == 0 || hasGenre(tvShow.Genres, query.GenreID) {
+			tvShows = append(tvShows, cloneTVShow(tvShow))
+		}
+	}
+
+	return paginate(tvShows, catalogListing(query.Sort, func(t *domain.TVShow) catalogFields {
+		return catalogFields{
+			id:        t.ID,
+			vote:      t.VoteAverage,
+			voteCount: t.VoteCount,
+			release:   t.FirstAirDate,
+			createdAt: t.CreatedAt,
+		}
+	}), page)
+}
+
+func (r *tvShowRepository) ListStale(ctx context.Context, updatedBefore time.Time, after domain.RefreshPosition, limit int) ([]*domain.TVShow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var tvShows []*domain.TVShow
+	for _, tvShow := range r.tvShows {
+		if tvShow.DeletedAt == nil && tvShow.UpdatedAt.Before(updatedBefore) && isAfter(tvShow.UpdatedAt, tvShow.ID, after) {
+			tvShows = append(tvShows, cloneTVShow(tvShow))
+		}
+	}
+	sort.Slice(tvShows, func(i, j int) bool {
+		return positionLess(tvShows[i].UpdatedAt, tvShows[i].ID, tvShows[j].UpdatedAt, tvShows[j].ID)
+	})
+
+	return textsearch.Window(tvShows, limit, 0), nil
+}
+
+// byTMDBID finds a stored TV show, deleted or not. Callers hold the lock.
+func (r *tvShowRepository) byTMDBID(tmdbID int) *domain.TVShow {
+	for _, tvShow := range r.tvShows {
+		if tvShow.TMDBTVShowID == tmdbID {
+			return tvShow
+		}
+	}
+	return nil
+}
+
+// visible lists stored TV shows that are neither hidden nor deleted. Callers
+// hold the lock.
+func (r *tvShowRepository) visible() []*domain.TVShow {
+	var tvShows []*domain.TVShow
+	for _, tvShow := range r.tvShows {
+		if !tvShow.Hidden && tvShow.DeletedAt == nil {
+			tvShows = append(tvShows, tvShow)
+		}
+	}
+	return tvShows
+}
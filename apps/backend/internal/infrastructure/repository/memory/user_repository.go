@@ -0,0 +1,170 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type userRepository struct {
+	mu    sync.RWMutex
+	users map[primitive.ObjectID]*domain.User
+}
+
+func NewUserRepository() domain.UserRepository {
+	return &userRepository{
+		users: make(map[primitive.ObjectID]*domain.User),
+	}
+}
+
+func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.findOne(func(u *domain.User) bool { return u.ID == objectID })
+}
+
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.findOne(func(u *domain.User) bool { return u.Email == email })
+}
+
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return r.findOne(func(u *domain.User) bool { return u.Username == username })
+}
+
+func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	user.ID = primitive.NewObjectID()
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+	user.Version = 1
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.users[user.ID] = cloneUser(user)
+	return nil
+}
+
+func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[user.ID]
+	if !ok || stored.DeletedAt != nil {
+		return domain.ErrNotFound
+	}
+	if stored.Version != user.Version {
+		return domain.ErrVersionConflict
+	}
+
+	user.UpdatedAt = time.Now()
+	user.Version++
+	r.users[user.ID] = cloneUser(user)
+	return nil
+}
+
+// Delete soft-deletes a user; it stays stored until purged.
+func (r *userRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[objectID]
+	if !ok || user.DeletedAt != nil {
+		return domain.ErrNotFound
+	}
+	now := time.Now()
+	user.DeletedAt = &now
+	user.Version++
+	return nil
+}
+
+func (r *userRepository) Restore(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[objectID]
+	if !ok || user.DeletedAt == nil {
+		return domain.ErrNotFound
+	}
+	user.DeletedAt = nil
+	user.UpdatedAt = time.Now()
+	user.Version++
+	return nil
+}
+
+func (r *userRepository) ListDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := []string{}
+	for id, user := range r.users {
+		if user.DeletedAt != nil && user.DeletedAt.Before(deletedBefore) {
+			ids = append(ids, id.Hex())
+		}
+	}
+	return ids, nil
+}
+
+func (r *userRepository) Purge(ctx context.Context, ids []string, deletedBefore time.Time) ([]string, error) {
+	purge, err := objectIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := make([]string, 0, len(purge))
+	for id := range purge {
+		if user, ok := r.users[id]; ok {
+			if user.DeletedAt == nil || !user.DeletedAt.Before(deletedBefore) {
+				continue
+			}
+			delete(r.users, id)
+		}
+		purged = append(purged, id.Hex())
+	}
+	return purged, nil
+}
+
+func (r *userRepository) List(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.User], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var users []*domain.User
+	for _, user := range r.users {
+		if user.DeletedAt == nil {
+			users = append(users, cloneUser(user))
+		}
+	}
+
+	return paginate(users, byID(func(u *domain.User) primitive.ObjectID { return u.ID }), page)
+}
+
+func (r *userRepository) findOne(match func(*domain.User) bool) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.DeletedAt == nil && match(user) {
+			return cloneUser(user), nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
@@ -0,0 +1,166 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type watchlistRepository struct {
+	mu      sync.RWMutex
+	entries map[primitive.ObjectID]*domain.Watchlist
+}
+
+func NewWatchlistRepository() domain.WatchlistRepository {
+	return &watchlistRepository{
+		entries: make(map[primitive.ObjectID]*domain.Watchlist),
+	}
+}
+
+// GetByUserID lists a user's watchlist, most recently added first.
+func (r *watchlistRepository) GetByUserID(ctx context.Context, userID string, page domain.PageRequest) (*domain.Page[*domain.Watchlist], error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entries []*domain.Watchlist
+	for _, entry := range r.entries {
+		if entry.UserID == userObjectID {
+			entries = append(entries, cloneWatchlist(entry))
+		}
+	}
+
+	return paginate(entries, newestFirst(
+		func(w *domain.Watchlist) time.Time { return w.AddedAt },
+		func(w *domain.Watchlist) primitive.ObjectID { return w.ID },
+	), page)
+}
+
+func (r *watchlistRepository) Add(ctx context.Context, watchlist *domain.Watchlist) error {
+	if watchlist.ID.IsZero() {
+		watchlist.ID = primitive.NewObjectID()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.entries[watchlist.ID]; ok {
+		return errDuplicateKey
+	}
+	r.entries[watchlist.ID] = cloneWatchlist(watchlist)
+	return nil
+}
+
+func (r *watchlistRepository) Remove(ctx context.Context, userID, itemID string, itemType string) error {
+	match, err := userItemMatcher(userID, itemID, itemType)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, entry := range r.entries {
+		if match(entry.UserID, entry.Type, entry.MovieID, entry.TVShowID) {
+			delete(r.entries, id)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *watchlistRepository) IsInWatchlist(ctx context.Context, userID, itemID string, itemType string) (bool, error) {
+	match, err := userItemMatcher(userID, itemID, itemType)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, entry := range r.entries {
+		if match(entry.UserID, entry.Type, entry.MovieID, entry.TVShowID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RemoveByItems drops every watchlist entry for the given movies or TV
+// shows.
+func (r *watchlistRepository) RemoveByItems(ctx context.Context, itemIDs []string, itemType string) error {
+	items, err := objectIDs(itemIDs)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, entry := range r.entries {
+		if refersToAny(items, itemType, entry.Type, entry.MovieID, entry.TVShowID) {
+			delete(r.entries, id)
+		}
+	}
+	return nil
+}
+
+// RemoveByUsers drops every watchlist entry of the given users.
+func (r *watchlistRepository) RemoveByUsers(ctx context.Context, userIDs []string) error {
+	users, err := objectIDs(userIDs)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, entry := range r.entries {
+		if users[entry.UserID] {
+			delete(r.entries, id)
+		}
+	}
+	return nil
+}
+
+// itemRef returns the movie or TV show ID an entry of the given type points
+// at, mirroring the Mongo itemFilter.
+func itemRef(itemType string, movieID, tvShowID *primitive.ObjectID) *primitive.ObjectID {
+	if itemType == "tv" {
+		return tvShowID
+	}
+	return movieID
+}
+
+// refersToAny reports whether an entry points at one of items of itemType.
+func refersToAny(items map[primitive.ObjectID]bool, itemType, entryType string, movieID, tvShowID *primitive.ObjectID) bool {
+	if entryType != itemType {
+		return false
+	}
+	ref := itemRef(itemType, movieID, tvShowID)
+	return ref != nil && items[*ref]
+}
+
+// userItemMatcher matches one user's watchlist or rating entry for an item.
+func userItemMatcher(userID, itemID, itemType string) (func(primitive.ObjectID, string, *primitive.ObjectID, *primitive.ObjectID) bool, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := objectIDs([]string{itemID})
+	if err != nil {
+		return nil, err
+	}
+
+	return func(entryUser primitive.ObjectID, entryType string, movieID, tvShowID *primitive.ObjectID) bool {
+		return entryUser == userObjectID && refersToAny(items, itemType, entryType, movieID, tvShowID)
+	}, nil
+}
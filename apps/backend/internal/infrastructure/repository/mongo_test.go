@@ -0,0 +1,48 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"backend/internal/domain"
+	"backend/internal/infrastructure/migrations"
+	"backend/internal/infrastructure/repository"
+	"backend/internal/infrastructure/repository/repotest"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TestRepositories runs the checks against the MongoDB server named by
+// MONGO_TEST_URI, each in a freshly migrated scratch database dropped
+// afterwards. It is skipped when the variable isn't set.
+func TestRepositories(t *testing.T) {
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Disconnect(context.Background()) })
+	if err := client.Ping(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	repotest.Run(t, func(t *testing.T) domain.Repositories {
+		db := client.Database(fmt.Sprintf("repotest_%d", time.Now().UnixNano()))
+		t.Cleanup(func() { db.Drop(context.Background()) })
+
+		if _, err := migrations.NewRunner(db).Up(context.Background()); err != nil {
+			t.Fatalf("migrating: %v", err)
+		}
+		return repository.New(db)
+	})
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"reflect"
 	"time"
 
 	"backend/internal/domain"
@@ -29,9 +30,9 @@ func (r *movieRepository) GetByID(ctx context.Context, id string) (*domain.Movie
 	}
 
 	var movie domain.Movie
-	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&movie)
+	err = r.collection.FindOne(ctx, live(bson.M{"_id": objectID})).Decode(&movie)
 	if err != nil {
-		return nil, err
+		return nil, notFound(err)
 	}
 
 	return &movie, nil
@@ -39,136 +40,225 @@ func (r *movieRepository) GetByID(ctx context.Context, id string) (*domain.Movie
 
 func (r *movieRepository) GetByTMDBID(ctx context.Context, tmdbID int) (*domain.Movie, error) {
 	var movie domain.Movie
-	err := r.collection.FindOne(ctx, bson.M{"tmdbMovieId": tmdbID}).Decode(&movie)
+	err := r.collection.FindOne(ctx, live(bson.M{"tmdbMovieId": tmdbID})).Decode(&movie)
 	if err != nil {
-		return nil, err
+		return nil, notFound(err)
 	}
 
 	return &movie, nil
 }
 
+func (r *movieRepository) GetByTMDBIDs(ctx context.Context, tmdbIDs []int) ([]*domain.Movie, error) {
+	cursor, err := r.collection.Find(ctx, live(bson.M{"tmdbMovieId": bson.M{"$in": tmdbIDs}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var movies []*domain.Movie
+	if err = cursor.All(ctx, &movies); err != nil {
+		return nil, err
+	}
+
+	return movies, nil
+}
+
+func (r *movieRepository) GetTMDBIDs(ctx context.Context, ids []string) (map[string]int, error) {
+	return tmdbIDsByID(ctx, r.collection, "tmdbMovieId", ids)
+}
+
 func (r *movieRepository) Create(ctx context.Context, movie *domain.Movie) error {
 	movie.ID = primitive.NewObjectID()
 	movie.CreatedAt = time.Now()
 	movie.UpdatedAt = time.Now()
+	movie.Version = 1
 
-	_, err := r.collection.InsertOne(ctx, movie)
+	_, err := r.collection.InsertOne(ctx, newMovieDocument(movie))
 	return err
 }
 
-func (r *movieRepository) Update(ctx context.Context, movie *domain.Movie) error {
-	movie.UpdatedAt = time.Now()
-
-	filter := bson.M{"_id": movie.ID}
-	update := bson.M{"$set": movie}
+// Upsert stores a movie fetched in full from TMDB, keyed on its TMDB ID.
+func (r *movieRepository) Upsert(ctx context.Context, movie *domain.Movie) error {
+	return r.upsert(ctx, []*domain.Movie{movie}, (*domain.Movie).ApplyUpstream, movieUpstreamFields)
+}
 
-	_, err := r.collection.UpdateOne(ctx, filter, update)
-	return err
+// UpsertMany stores movies from a TMDB list result. Stored movies only get
+// the fields list results carry refreshed; new ones are inserted whole.
+func (r *movieRepository) UpsertMany(ctx context.Context, movies []*domain.Movie) error {
+	return r.upsert(ctx, movies, (*domain.Movie).ApplyListing, movieListingFields)
 }
 
-func (r *movieRepository) Delete(ctx context.Context, id string) error {
-	objectID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return err
+// upsert inserts the movies not stored yet and refreshes the stored ones with
+// apply, writing the fields that set lists in a single bulk write. A stored
+// movie that apply leaves unchanged isn't written, so its version stays put.
+// The stored ID, CreatedAt, UpdatedAt, Hidden flag, DeletedAt and Version are
+// copied back onto each movie. Soft-deleted titles stay deleted.
+func (r *movieRepository) upsert(ctx context.Context, movies []*domain.Movie, apply func(stored, upstream *domain.Movie), set func(*domain.Movie) bson.M) error {
+	if len(movies) == 0 {
+		return nil
 	}
 
-	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
-	return err
-}
-
-func (r *movieRepository) List(ctx context.Context, limit, offset int) ([]*domain.Movie, int64, error) {
-	opts := options.Find().SetLimit(int64(limit)).SetSkip(int64(offset))
-	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	tmdbIDs := make([]int, 0, len(movies))
+	for _, movie := range movies {
+		tmdbIDs = append(tmdbIDs, movie.TMDBMovieID)
+	}
+	byTMDBID, err := r.storedByTMDBID(ctx, tmdbIDs, nil)
 	if err != nil {
-		return nil, 0, err
+		return err
 	}
-	defer cursor.Close(ctx)
 
-	var movies []*domain.Movie
-	if err = cursor.All(ctx, &movies); err != nil {
-		return nil, 0, err
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(movies))
+	for _, movie := range movies {
+		stored, ok := byTMDBID[movie.TMDBMovieID]
+		if !ok {
+			writes = append(writes, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"tmdbMovieId": movie.TMDBMovieID}).
+				SetUpdate(bson.M{"$setOnInsert": insertFields(movieUpstreamFields(movie), now)}).
+				SetUpsert(true))
+			continue
+		}
+
+		updated := stored
+		apply(&updated, movie)
+		if reflect.DeepEqual(updated, stored) {
+			continue
+		}
+		fields := set(&updated)
+		fields["updatedAt"] = now
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": stored.ID, "version": versionFilter(stored.Version)}).
+			SetUpdate(bson.M{"$set": fields, "$inc": bumpVersion}))
+	}
+	if len(writes) == 0 {
+		copyMovieBookkeeping(movies, byTMDBID)
+		return nil
 	}
 
-	total, err := r.collection.CountDocuments(ctx, bson.M{})
-	if err != nil {
-		return nil, 0, err
+	if _, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return err
 	}
 
-	return movies, total, nil
+	projection := bson.M{"_id": 1, "tmdbMovieId": 1, "createdAt": 1, "updatedAt": 1, "hidden": 1, "deletedAt": 1, "version": 1}
+	if byTMDBID, err = r.storedByTMDBID(ctx, tmdbIDs, projection); err != nil {
+		return err
+	}
+	copyMovieBookkeeping(movies, byTMDBID)
+	return nil
 }
 
-func (r *movieRepository) Search(ctx context.Context, query string, limit, offset int) ([]*domain.Movie, int64, error) {
-	filter := bson.M{
-		"$or": []bson.M{
-			{"title": bson.M{"$regex": query, "$options": "i"}},
-			{"overview": bson.M{"$regex": query, "$options": "i"}},
-		},
+// storedByTMDBID loads the movies with the given TMDB IDs, soft-deleted ones
+// included, keyed on their TMDB ID. A nil projection loads whole documents.
+func (r *movieRepository) storedByTMDBID(ctx context.Context, tmdbIDs []int, projection bson.M) (map[int]domain.Movie, error) {
+	opts := options.Find()
+	if projection != nil {
+		opts.SetProjection(projection)
 	}
-
-	opts := options.Find().SetLimit(int64(limit)).SetSkip(int64(offset))
-	cursor, err := r.collection.Find(ctx, filter, opts)
+	cursor, err := r.collection.Find(ctx, bson.M{"tmdbMovieId": bson.M{"$in": tmdbIDs}}, opts)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	var movies []*domain.Movie
-	if err = cursor.All(ctx, &movies); err != nil {
-		return nil, 0, err
+	var stored []domain.Movie
+	if err = cursor.All(ctx, &stored); err != nil {
+		return nil, err
 	}
 
-	total, err := r.collection.CountDocuments(ctx, filter)
-	if err != nil {
-		return nil, 0, err
+	byTMDBID := make(map[int]domain.Movie, len(stored))
+	for _, s := range stored {
+		byTMDBID[s.TMDBMovieID] = s
 	}
+	return byTMDBID, nil
+}
 
-	return movies, total, nil
+func copyMovieBookkeeping(movies []*domain.Movie, byTMDBID map[int]domain.Movie) {
+	for _, movie := range movies {
+		if s, ok := byTMDBID[movie.TMDBMovieID]; ok {
+			movie.ID = s.ID
+			movie.CreatedAt = s.CreatedAt
+			movie.UpdatedAt = s.UpdatedAt
+			movie.Hidden = s.Hidden
+			movie.DeletedAt = s.DeletedAt
+			movie.Version = s.Version
+		}
+	}
 }
 
-func (r *movieRepository) GetByGenre(ctx context.Context, genreID int, limit, offset int) ([]*domain.Movie, int64, error) {
-	filter := bson.M{"genres.id": genreID}
+func (r *movieRepository) Update(ctx context.Context, movie *domain.Movie) error {
+	movie.UpdatedAt = time.Now()
+	movie.Version++
 
-	opts := options.Find().SetLimit(int64(limit)).SetSkip(int64(offset))
-	cursor, err := r.collection.Find(ctx, filter, opts)
-	if err != nil {
-		return nil, 0, err
+	if err := updateVersioned(ctx, r.collection, movie.ID, movie.Version-1, newMovieDocument(movie)); err != nil {
+		movie.Version--
+		return err
 	}
-	defer cursor.Close(ctx)
+	return nil
+}
 
-	var movies []*domain.Movie
-	if err = cursor.All(ctx, &movies); err != nil {
-		return nil, 0, err
-	}
+// Delete soft-deletes a movie; it stays stored until purged.
+func (r *movieRepository) Delete(ctx context.Context, id string) error {
+	return softDelete(ctx, r.collection, id)
+}
 
-	total, err := r.collection.CountDocuments(ctx, filter)
-	if err != nil {
-		return nil, 0, err
+func (r *movieRepository) Restore(ctx context.Context, id string) error {
+	return restore(ctx, r.collection, id)
+}
+
+func (r *movieRepository) ListDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	return listDeleted(ctx, r.collection, deletedBefore)
+}
+
+func (r *movieRepository) Purge(ctx context.Context, ids []string, deletedBefore time.Time) ([]string, error) {
+	return purge(ctx, r.collection, ids, deletedBefore)
+}
+
+func (r *movieRepository) List(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.Movie], error) {
+	return r.Browse(ctx, domain.CatalogQuery{}, page)
+}
+
+// Search ranks movies by relevance using the weighted text index. When the
+// text index finds nothing, it falls back to a title prefix match so partial
+// words still return results, unscored. Input made only of search operators
+// matches nothing, and the total is only counted when withTotal is set.
+func (r *movieRepository) Search(ctx context.Context, query string, limit, offset int, withTotal bool) ([]*domain.MovieSearchResult, *int64, error) {
+	filter, ok := textSearchFilter(query)
+	if !ok {
+		return nil, noSearchTotal(withTotal), nil
+	}
+	results, total, matched, err := findSearchPage[*domain.MovieSearchResult](ctx, r.collection, filter, textScoreFindOptions(limit, offset), offset, withTotal)
+	if err != nil || matched {
+		return results, total, err
 	}
 
-	return movies, total, nil
+	results, total, _, err = findSearchPage[*domain.MovieSearchResult](ctx, r.collection, prefixSearchFilter("titleKey", query), prefixFindOptions("titleKey", limit, offset), offset, withTotal)
+	return results, total, err
 }
 
-func (r *movieRepository) GetPopular(ctx context.Context, limit, offset int) ([]*domain.Movie, int64, error) {
-	opts := options.Find().
-		SetLimit(int64(limit)).
-		SetSkip(int64(offset)).
-		SetSort(bson.M{"voteAverage": -1, "voteCount": -1})
+func (r *movieRepository) GetByGenre(ctx context.Context, genreID int, page domain.PageRequest) (*domain.Page[*domain.Movie], error) {
+	return r.Browse(ctx, domain.CatalogQuery{GenreID: genreID}, page)
+}
 
-	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+func (r *movieRepository) GetPopular(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.Movie], error) {
+	return r.Browse(ctx, domain.CatalogQuery{Sort: domain.SortByVote}, page)
+}
+
+// Browse lists one page of stored movies matching the query.
+func (r *movieRepository) Browse(ctx context.Context, query domain.CatalogQuery, page domain.PageRequest) (*domain.Page[*domain.Movie], error) {
+	return findPage[*domain.Movie](ctx, r.collection, catalogFilter(query), catalogSort(query.Sort, "releaseDate"), page)
+}
+
+func (r *movieRepository) ListStale(ctx context.Context, updatedBefore time.Time, after domain.RefreshPosition, limit int) ([]*domain.Movie, error) {
+	cursor, err := r.collection.Find(ctx, staleFilter(updatedBefore, after), staleFindOptions(limit))
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	defer cursor.Close(ctx)
 
 	var movies []*domain.Movie
 	if err = cursor.All(ctx, &movies); err != nil {
-		return nil, 0, err
-	}
-
-	total, err := r.collection.CountDocuments(ctx, bson.M{})
-	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	return movies, total, nil
+	return movies, nil
 }
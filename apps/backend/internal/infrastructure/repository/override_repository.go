@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type overrideRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOverrideRepository(db *mongo.Database) domain.OverrideRepository {
+	return &overrideRepository{
+		collection: db.Collection("catalog_overrides"),
+	}
+}
+
+func (r *overrideRepository) Get(ctx context.Context, mediaType string, tmdbID int) (*domain.CatalogOverride, error) {
+	var override domain.CatalogOverride
+	err := r.collection.FindOne(ctx, bson.M{"mediaType": mediaType, "tmdbId": tmdbID}).Decode(&override)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &override, nil
+}
+
+func (r *overrideRepository) GetMany(ctx context.Context, mediaType string, tmdbIDs []int) ([]*domain.CatalogOverride, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"mediaType": mediaType, "tmdbId": bson.M{"$in": tmdbIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var overrides []*domain.CatalogOverride
+	if err = cursor.All(ctx, &overrides); err != nil {
+		return nil, err
+	}
+
+	return overrides, nil
+}
+
+// Save replaces the stored override for the same media type and TMDB ID,
+// creating it when missing.
+func (r *overrideRepository) Save(ctx context.Context, override *domain.CatalogOverride) error {
+	override.UpdatedAt = time.Now()
+
+	filter := bson.M{"mediaType": override.MediaType, "tmdbId": override.TMDBID}
+	update := bson.M{"$set": bson.M{
+		"fields":    override.Fields,
+		"updatedAt": override.UpdatedAt,
+	}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	return r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(override)
+}
+
+func (r *overrideRepository) Delete(ctx context.Context, mediaType string, tmdbID int) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"mediaType": mediaType, "tmdbId": tmdbID})
+	return err
+}
+
+func (r *overrideRepository) DeleteMany(ctx context.Context, mediaType string, tmdbIDs []int) error {
+	if len(tmdbIDs) == 0 {
+		return nil
+	}
+	_, err := r.collection.DeleteMany(ctx, bson.M{"mediaType": mediaType, "tmdbId": bson.M{"$in": tmdbIDs}})
+	return err
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const defaultPageLimit = 20
+
+// sortFieldTypes are the BSON types a cursor may carry for each field
+// findPage sorts on; cursors for any other field are rejected, so add new
+// sort fields here. Documents missing a sort field give null, except _id.
+var sortFieldTypes = map[string][]bsontype.Type{
+	"_id":          {bson.TypeObjectID},
+	"voteAverage":  {bson.TypeDouble, bson.TypeInt32, bson.TypeInt64, bson.TypeNull},
+	"voteCount":    {bson.TypeInt32, bson.TypeInt64, bson.TypeNull},
+	"releaseDate":  {bson.TypeString, bson.TypeNull},
+	"firstAirDate": {bson.TypeString, bson.TypeNull},
+	"createdAt":    {bson.TypeDateTime, bson.TypeNull},
+	"addedAt":      {bson.TypeDateTime, bson.TypeNull},
+}
+
+// findPage runs a keyset-paginated query. sort must end in a unique key
+// (normally _id) so every document has a distinct position. Cursors carry
+// the sort key values of the last document on the page, BSON-encoded so
+// their types survive the round trip.
+func findPage[T any](ctx context.Context, collection *mongo.Collection, filter bson.M, sort bson.D, page domain.PageRequest) (*domain.Page[T], error) {
+	if page.Limit <= 0 {
+		page.Limit = defaultPageLimit
+	}
+
+	query := filter
+	if page.Cursor != "" {
+		after, err := decodeCursor(page.Cursor, sort)
+		if err != nil {
+			return nil, err
+		}
+		query = bson.M{"$and": bson.A{filter, keysetFilter(sort, after)}}
+	}
+
+	opts := options.Find().SetSort(sort).SetLimit(int64(page.Limit + 1))
+	cursor, err := collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var items []T
+	if err = cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	result := &domain.Page[T]{Items: items}
+	if len(items) > page.Limit {
+		result.Items = items[:page.Limit]
+		if result.NextCursor, err = encodeCursor(sort, result.Items[page.Limit-1]); err != nil {
+			return nil, err
+		}
+	}
+
+	if page.WithTotal {
+		total, err := collection.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		result.Total = &total
+	}
+
+	return result, nil
+}
+
+// keysetFilter matches documents positioned strictly after the cursor
+// values in the given sort order.
+func keysetFilter(sort bson.D, after bson.D) bson.M {
+	branches := make(bson.A, 0, len(sort))
+	for i, key := range sort {
+		branch := bson.M{}
+		for j := 0; j < i; j++ {
+			branch[sort[j].Key] = after[j].Value
+		}
+		op := "$gt"
+		if direction, ok := key.Value.(int); ok && direction < 0 {
+			op = "$lt"
+		}
+		branch[key.Key] = bson.M{op: after[i].Value}
+		branches = append(branches, branch)
+	}
+	return bson.M{"$or": branches}
+}
+
+func encodeCursor(sort bson.D, last interface{}) (string, error) {
+	raw, err := bson.Marshal(last)
+	if err != nil {
+		return "", err
+	}
+
+	values := make(bson.D, 0, len(sort))
+	for _, key := range sort {
+		var value interface{}
+		if v, err := bson.Raw(raw).LookupErr(key.Key); err == nil {
+			value = v
+		}
+		values = append(values, bson.E{Key: key.Key, Value: value})
+	}
+
+	encoded, err := bson.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
+
+// decodeCursor unpacks a cursor and checks it was issued for the same sort
+// and holds values of the types that sort compares. Anything else, such as
+// a document smuggling query operators, is rejected.
+func decodeCursor(cursor string, sort bson.D) (bson.D, error) {
+	encoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	raw := bson.Raw(encoded)
+	if err := raw.Validate(); err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	elements, err := raw.Elements()
+	if err != nil || len(elements) != len(sort) {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	values := make(bson.D, len(sort))
+	for i, key := range sort {
+		value := elements[i].Value()
+		if elements[i].Key() != key.Key || !sortFieldType(key.Key, value.Type) {
+			return nil, domain.ErrInvalidCursor
+		}
+		values[i] = bson.E{Key: key.Key, Value: value}
+	}
+
+	return values, nil
+}
+
+func sortFieldType(field string, t bsontype.Type) bool {
+	for _, allowed := range sortFieldTypes[field] {
+		if t == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	movie := &domain.Movie{
+		ID:          primitive.NewObjectID(),
+		TMDBMovieID: 603,
+		Title:       "The Matrix",
+		ReleaseDate: "1999-03-31",
+		VoteAverage: 8.2,
+		VoteCount:   25000,
+		CreatedAt:   time.Now(),
+	}
+	sorts := map[string]bson.D{
+		"default":      catalogSort("", "releaseDate"),
+		"vote":         catalogSort(domain.SortByVote, "releaseDate"),
+		"release date": catalogSort(domain.SortByReleaseDate, "releaseDate"),
+		"recent":       catalogSort(domain.SortByRecent, "releaseDate"),
+		"tv release":   catalogSort(domain.SortByReleaseDate, "firstAirDate"),
+		"ratings":      newestFirst,
+		"watchlist":    recentlyAdded,
+		"users":        {{Key: "_id", Value: 1}},
+	}
+	for name, sort := range sorts {
+		t.Run(name, func(t *testing.T) {
+			cursor, err := encodeCursor(sort, movie)
+			if err != nil {
+				t.Fatal(err)
+			}
+			values, err := decodeCursor(cursor, sort)
+			if err != nil {
+				t.Fatalf("decoding a cursor we issued: %v", err)
+			}
+			if got := values[len(values)-1].Value.(bson.RawValue).ObjectID(); got != movie.ID {
+				t.Errorf("cursor _id is %s, want %s", got.Hex(), movie.ID.Hex())
+			}
+		})
+	}
+
+	t.Run("missing sort field", func(t *testing.T) {
+		sort := catalogSort(domain.SortByReleaseDate, "releaseDate")
+		cursor, err := encodeCursor(sort, bson.M{"_id": movie.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := decodeCursor(cursor, sort); err != nil {
+			t.Errorf("decoding a cursor with a null sort value: %v", err)
+		}
+	})
+}
+
+func TestDecodeCursorRejectsTampering(t *testing.T) {
+	id := primitive.NewObjectID()
+	byRelease := catalogSort(domain.SortByReleaseDate, "releaseDate")
+	byRecent := catalogSort(domain.SortByRecent, "releaseDate")
+
+	tests := []struct {
+		name   string
+		sort   bson.D
+		cursor string
+	}{
+		{"not base64", byRelease, "%%%"},
+		{"not BSON", byRelease, base64.RawURLEncoding.EncodeToString([]byte("not bson"))},
+		{"operator document", byRelease, encode(t, bson.D{
+			{Key: "releaseDate", Value: bson.D{{Key: "$ne", Value: nil}}},
+			{Key: "_id", Value: id},
+		})},
+		{"array", byRelease, encode(t, bson.D{
+			{Key: "releaseDate", Value: bson.A{"1999-03-31", "2003-05-15"}},
+			{Key: "_id", Value: id},
+		})},
+		{"string for a date", byRecent, encode(t, bson.D{
+			{Key: "createdAt", Value: "2024-01-01"},
+			{Key: "_id", Value: id},
+		})},
+		{"string for an ID", byRelease, encode(t, bson.D{
+			{Key: "releaseDate", Value: "1999-03-31"},
+			{Key: "_id", Value: id.Hex()},
+		})},
+		{"null ID", byRelease, encode(t, bson.D{
+			{Key: "releaseDate", Value: "1999-03-31"},
+			{Key: "_id", Value: nil},
+		})},
+		{"other sort", byRecent, encode(t, bson.D{
+			{Key: "releaseDate", Value: "1999-03-31"},
+			{Key: "_id", Value: id},
+		})},
+		{"extra key", byRelease, encode(t, bson.D{
+			{Key: "releaseDate", Value: "1999-03-31"},
+			{Key: "_id", Value: id},
+			{Key: "title", Value: "The Matrix"},
+		})},
+		{"unknown sort field", bson.D{{Key: "title", Value: 1}}, encode(t, bson.D{
+			{Key: "title", Value: "The Matrix"},
+		})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.cursor, tt.sort); !errors.Is(err, domain.ErrInvalidCursor) {
+				t.Errorf("decodeCursor returned %v, want %v", err, domain.ErrInvalidCursor)
+			}
+		})
+	}
+}
+
+func encode(t *testing.T, values bson.D) string {
+	t.Helper()
+	raw, err := bson.Marshal(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
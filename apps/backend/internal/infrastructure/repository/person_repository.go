@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type personRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPersonRepository(db *mongo.Database) domain.PersonRepository {
+	return &personRepository{
+		collection: db.Collection("people"),
+	}
+}
+
+func (r *personRepository) GetByTMDBID(ctx context.Context, tmdbID int) (*domain.Person, error) {
+	var person domain.Person
+	err := r.collection.FindOne(ctx, bson.M{"tmdbPersonId": tmdbID}).Decode(&person)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	return &person, nil
+}
+
+func (r *personRepository) GetByTMDBIDs(ctx context.Context, tmdbIDs []int) ([]*domain.Person, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"tmdbPersonId": bson.M{"$in": tmdbIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var people []*domain.Person
+	if err = cursor.All(ctx, &people); err != nil {
+		return nil, err
+	}
+
+	return people, nil
+}
+
+// Upsert inserts the person when it isn't stored yet and otherwise writes
+// it over the stored one, versioned, when anything changed.
+func (r *personRepository) Upsert(ctx context.Context, person *domain.Person) error {
+	stored, err := r.GetByTMDBID(ctx, person.TMDBPersonID)
+	if errors.Is(err, domain.ErrNotFound) {
+		now := time.Now()
+		person.ID = primitive.NewObjectID()
+		person.CreatedAt = now
+		person.UpdatedAt = now
+		person.Version = 1
+
+		result, err := r.collection.UpdateOne(ctx,
+			bson.M{"tmdbPersonId": person.TMDBPersonID},
+			bson.M{"$setOnInsert": person},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil || result.UpsertedCount > 0 {
+			return err
+		}
+		// Someone else stored it first; refresh theirs instead
+		stored, err = r.GetByTMDBID(ctx, person.TMDBPersonID)
+	}
+	if err != nil {
+		return err
+	}
+
+	person.ID = stored.ID
+	person.CreatedAt = stored.CreatedAt
+	person.UpdatedAt = stored.UpdatedAt
+	person.Version = stored.Version
+	if reflect.DeepEqual(person, stored) {
+		return nil
+	}
+
+	person.UpdatedAt = time.Now()
+	person.Version++
+	if err := updateVersioned(ctx, r.collection, person.ID, stored.Version, person); err != nil {
+		person.Version--
+		return err
+	}
+	return nil
+}
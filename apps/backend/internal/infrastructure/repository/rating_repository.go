@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ratingRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRatingRepository(db *mongo.Database) domain.RatingRepository {
+	return &ratingRepository{
+		collection: db.Collection("ratings"),
+	}
+}
+
+func (r *ratingRepository) GetByUserAndItem(ctx context.Context, userID, itemID string, itemType string) (*domain.Rating, error) {
+	filter, err := userItemFilter(userID, itemID, itemType)
+	if err != nil {
+		return nil, err
+	}
+
+	var rating domain.Rating
+	if err := r.collection.FindOne(ctx, filter).Decode(&rating); err != nil {
+		return nil, notFound(err)
+	}
+
+	return &rating, nil
+}
+
+// GetByItem lists the ratings of one movie or TV show, newest first.
+func (r *ratingRepository) GetByItem(ctx context.Context, itemID string, itemType string, page domain.PageRequest) (*domain.Page[*domain.Rating], error) {
+	filter, err := itemFilter(itemID, itemType)
+	if err != nil {
+		return nil, err
+	}
+
+	return findPage[*domain.Rating](ctx, r.collection, filter, newestFirst, page)
+}
+
+// GetByUser lists a user's ratings, newest first.
+func (r *ratingRepository) GetByUser(ctx context.Context, userID string, page domain.PageRequest) (*domain.Page[*domain.Rating], error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return findPage[*domain.Rating](ctx, r.collection, bson.M{"userId": userObjectID}, newestFirst, page)
+}
+
+func (r *ratingRepository) Create(ctx context.Context, rating *domain.Rating) error {
+	rating.ID = primitive.NewObjectID()
+	rating.CreatedAt = time.Now()
+	rating.UpdatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, rating)
+	return err
+}
+
+func (r *ratingRepository) Update(ctx context.Context, rating *domain.Rating) error {
+	rating.UpdatedAt = time.Now()
+
+	filter := bson.M{"_id": rating.ID}
+	update := bson.M{"$set": rating}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+func (r *ratingRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	return err
+}
+
+// GetAverageRating returns the mean rating of an item and how many ratings
+// it is based on.
+func (r *ratingRepository) GetAverageRating(ctx context.Context, itemID string, itemType string) (float64, int64, error) {
+	filter, err := itemFilter(itemID, itemType)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     nil,
+			"average": bson.M{"$avg": "$rating"},
+			"count":   bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var result struct {
+		Average float64 `bson:"average"`
+		Count   int64   `bson:"count"`
+	}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&result); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return result.Average, result.Count, cursor.Err()
+}
+
+var newestFirst = bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: 1}}
+
+// DeleteByItems drops every rating of the given movies or TV shows.
+func (r *ratingRepository) DeleteByItems(ctx context.Context, itemIDs []string, itemType string) error {
+	filter, err := itemsFilter(itemIDs, itemType)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteMany(ctx, filter)
+	return err
+}
+
+// DeleteByUsers drops every rating by the given users.
+func (r *ratingRepository) DeleteByUsers(ctx context.Context, userIDs []string) error {
+	userObjectIDs, err := objectIDs(userIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteMany(ctx, bson.M{"userId": bson.M{"$in": userObjectIDs}})
+	return err
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// staleFilter matches live documents last updated before the cutoff that
+// sort after the given position in (updatedAt, _id) order.
+func staleFilter(updatedBefore time.Time, after domain.RefreshPosition) bson.M {
+	filter := live(bson.M{"updatedAt": bson.M{"$lt": updatedBefore}})
+	if !after.ID.IsZero() {
+		filter["$or"] = []bson.M{
+			{"updatedAt": bson.M{"$gt": after.UpdatedAt}},
+			{"updatedAt": after.UpdatedAt, "_id": bson.M{"$gt": after.ID}},
+		}
+	}
+	return filter
+}
+
+func staleFindOptions(limit int) *options.FindOptions {
+	return options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "updatedAt", Value: 1}, {Key: "_id", Value: 1}})
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// New returns the MongoDB-backed repositories for db.
+func New(db *mongo.Database) domain.Repositories {
+	return domain.Repositories{
+		Movies:      NewMovieRepository(db),
+		TVShows:     NewTVShowRepository(db),
+		People:      NewPersonRepository(db),
+		Users:       NewUserRepository(db),
+		Watchlists:  NewWatchlistRepository(db),
+		Ratings:     NewRatingRepository(db),
+		Overrides:   NewOverrideRepository(db),
+		Checkpoints: NewCheckpointRepository(db),
+	}
+}
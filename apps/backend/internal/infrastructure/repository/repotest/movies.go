@@ -0,0 +1,237 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CheckMovieRepository checks lookups, versioned updates, upserts, browsing,
+// search and the soft delete lifecycle of a MovieRepository.
+func CheckMovieRepository(ctx context.Context, repo domain.MovieRepository) error {
+	_, err := repo.GetByID(ctx, missingID)
+	if err := expectError(err, domain.ErrNotFound, "movies: GetByID of a missing movie"); err != nil {
+		return err
+	}
+	_, err = repo.GetByTMDBID(ctx, -1)
+	if err := expectError(err, domain.ErrNotFound, "movies: GetByTMDBID of a missing movie"); err != nil {
+		return err
+	}
+
+	// Create and versioned updates
+	movie := &domain.Movie{TMDBMovieID: 900001, Title: "Contract Check", Overview: "A movie stored by the repository checks", Runtime: 120, Genres: []domain.Genre{{ID: 18, Name: "Drama"}}}
+	if err := repo.Create(ctx, movie); err != nil {
+		return fmt.Errorf("movies: Create: %w", err)
+	}
+	if movie.ID.IsZero() || movie.Version != 1 {
+		return fmt.Errorf("movies: Create set ID %s and version %d, want a new ID and version 1", movie.ID.Hex(), movie.Version)
+	}
+	stored, err := repo.GetByID(ctx, movie.ID.Hex())
+	if err != nil {
+		return fmt.Errorf("movies: GetByID after Create: %w", err)
+	}
+	if stored.Title != movie.Title || stored.Version != 1 {
+		return fmt.Errorf("movies: GetByID returned %q version %d", stored.Title, stored.Version)
+	}
+
+	stored.Title = "Contract Check Edited"
+	if err := repo.Update(ctx, stored); err != nil {
+		return fmt.Errorf("movies: Update: %w", err)
+	}
+	if stored.Version != 2 {
+		return fmt.Errorf("movies: Update left version %d, want 2", stored.Version)
+	}
+	movie.Title = "Stale Edit"
+	if err := expectError(repo.Update(ctx, movie), domain.ErrVersionConflict, "movies: Update with a stale version"); err != nil {
+		return err
+	}
+	if movie.Version != 1 {
+		return fmt.Errorf("movies: failed Update changed the version to %d", movie.Version)
+	}
+	if stored, err = repo.GetByTMDBID(ctx, 900001); err != nil {
+		return fmt.Errorf("movies: GetByTMDBID: %w", err)
+	}
+	if stored.Title != "Contract Check Edited" {
+		return fmt.Errorf("movies: stale Update overwrote the title with %q", stored.Title)
+	}
+
+	// List upserts keep the stored identity and the details lists don't carry
+	upserts := []*domain.Movie{
+		{TMDBMovieID: 900001, Title: "Contract Check Upstream", VoteAverage: 5},
+		{TMDBMovieID: 900002, Title: "Second Feature", VoteAverage: 9, Genres: []domain.Genre{{ID: 35, Name: "Comedy"}}},
+		{TMDBMovieID: 900003, Title: "Third Feature", VoteAverage: 7, Genres: []domain.Genre{{ID: 18, Name: "Drama"}}},
+	}
+	if err := repo.UpsertMany(ctx, upserts); err != nil {
+		return fmt.Errorf("movies: UpsertMany: %w", err)
+	}
+	if upserts[0].ID != movie.ID || upserts[0].Version != 3 {
+		return fmt.Errorf("movies: UpsertMany of a stored movie returned ID %s version %d, want %s version 3", upserts[0].ID.Hex(), upserts[0].Version, movie.ID.Hex())
+	}
+	for _, m := range upserts[1:] {
+		if m.ID.IsZero() || m.Version != 1 {
+			return fmt.Errorf("movies: UpsertMany of a new movie returned ID %s version %d", m.ID.Hex(), m.Version)
+		}
+	}
+	if stored, err = repo.GetByTMDBID(ctx, 900001); err != nil {
+		return fmt.Errorf("movies: GetByTMDBID after UpsertMany: %w", err)
+	}
+	if stored.Title != "Contract Check Upstream" || stored.Runtime != 120 || len(stored.Genres) != 1 {
+		return fmt.Errorf("movies: UpsertMany stored %q with runtime %d and %d genres", stored.Title, stored.Runtime, len(stored.Genres))
+	}
+	unchanged := []*domain.Movie{{TMDBMovieID: 900001, Title: "Contract Check Upstream", VoteAverage: 5}}
+	if err := repo.UpsertMany(ctx, unchanged); err != nil {
+		return fmt.Errorf("movies: UpsertMany of an unchanged movie: %w", err)
+	}
+	if unchanged[0].Version != 3 {
+		return fmt.Errorf("movies: UpsertMany of an unchanged movie moved it to version %d", unchanged[0].Version)
+	}
+	found, err := repo.GetByTMDBIDs(ctx, []int{900001, 900002, 900003, -1})
+	if err != nil {
+		return fmt.Errorf("movies: GetByTMDBIDs: %w", err)
+	}
+	if len(found) != 3 {
+		return fmt.Errorf("movies: GetByTMDBIDs found %d movies, want 3", len(found))
+	}
+
+	// Browsing pages through every visible movie in order
+	listed, err := collect(func(page domain.PageRequest) (*domain.Page[*domain.Movie], error) {
+		return repo.Browse(ctx, domain.CatalogQuery{Sort: domain.SortByVote}, page)
+	}, 2, movieID)
+	if err != nil {
+		return fmt.Errorf("movies: Browse by vote: %w", err)
+	}
+	if got := movieTMDBIDs(listed); fmt.Sprint(got) != "[900002 900003 900001]" {
+		return fmt.Errorf("movies: Browse by vote listed %v", got)
+	}
+	drama, err := repo.GetByGenre(ctx, 18, domain.PageRequest{Limit: 10, WithTotal: true})
+	if err != nil {
+		return fmt.Errorf("movies: GetByGenre: %w", err)
+	}
+	if drama.Total == nil || *drama.Total != 2 || len(drama.Items) != 2 {
+		return fmt.Errorf("movies: GetByGenre listed %v", movieTMDBIDs(drama.Items))
+	}
+	if _, err := repo.List(ctx, domain.PageRequest{Cursor: "not-a-cursor"}); err == nil {
+		return fmt.Errorf("movies: List accepted a malformed cursor")
+	}
+
+	// Search ranks whole words and falls back to title prefixes
+	results, total, err := repo.Search(ctx, "feature", 10, 0, true)
+	if err != nil {
+		return fmt.Errorf("movies: Search: %w", err)
+	}
+	if total == nil || *total != 2 || len(results) != 2 {
+		return fmt.Errorf("movies: Search for a word found %d results, want 2 counted", len(results))
+	}
+	if results, total, err = repo.Search(ctx, "Seco", 10, 0, false); err != nil {
+		return fmt.Errorf("movies: prefix Search: %w", err)
+	}
+	if len(results) != 1 || results[0].TMDBMovieID != 900002 || total != nil {
+		return fmt.Errorf("movies: prefix Search found %d results", len(results))
+	}
+	if results, _, err = repo.Search(ctx, "feature", 10, 5, false); err != nil || len(results) != 0 {
+		return fmt.Errorf("movies: Search past the last match fell back to prefixes: %d results, %v", len(results), err)
+	}
+	if results, _, err = repo.Search(ctx, `" -- "`, 10, 0, false); err != nil || len(results) != 0 {
+		return fmt.Errorf("movies: Search of bare operators found %d results, %v", len(results), err)
+	}
+
+	// Hidden movies drop out of listings but can still be fetched
+	hidden := upserts[2]
+	hidden.Hidden = true
+	if err := repo.Update(ctx, hidden); err != nil {
+		return fmt.Errorf("movies: hiding: %w", err)
+	}
+	popular, err := repo.GetPopular(ctx, domain.PageRequest{Limit: 10})
+	if err != nil {
+		return fmt.Errorf("movies: GetPopular: %w", err)
+	}
+	if got := movieTMDBIDs(popular.Items); fmt.Sprint(got) != "[900002 900001]" {
+		return fmt.Errorf("movies: GetPopular listed a hidden movie: %v", got)
+	}
+	if _, err := repo.GetByID(ctx, hidden.ID.Hex()); err != nil {
+		return fmt.Errorf("movies: GetByID of a hidden movie: %w", err)
+	}
+
+	// Soft delete, restore and purge
+	deleted := upserts[1]
+	if err := repo.Delete(ctx, deleted.ID.Hex()); err != nil {
+		return fmt.Errorf("movies: Delete: %w", err)
+	}
+	_, err = repo.GetByID(ctx, deleted.ID.Hex())
+	if err := expectError(err, domain.ErrNotFound, "movies: GetByID of a deleted movie"); err != nil {
+		return err
+	}
+	if err := expectError(repo.Delete(ctx, deleted.ID.Hex()), domain.ErrNotFound, "movies: deleting twice"); err != nil {
+		return err
+	}
+	deleted.Title = "Edited While Deleted"
+	if err := expectError(repo.Update(ctx, deleted), domain.ErrNotFound, "movies: Update of a deleted movie"); err != nil {
+		return err
+	}
+	if results, _, _ := repo.Search(ctx, "second", 10, 0, false); len(results) != 0 {
+		return fmt.Errorf("movies: Search found a deleted movie")
+	}
+	if err := repo.Restore(ctx, deleted.ID.Hex()); err != nil {
+		return fmt.Errorf("movies: Restore: %w", err)
+	}
+	if err := expectError(repo.Restore(ctx, deleted.ID.Hex()), domain.ErrNotFound, "movies: restoring a live movie"); err != nil {
+		return err
+	}
+	if _, err := repo.GetByID(ctx, deleted.ID.Hex()); err != nil {
+		return fmt.Errorf("movies: GetByID after Restore: %w", err)
+	}
+
+	if err := repo.Delete(ctx, deleted.ID.Hex()); err != nil {
+		return fmt.Errorf("movies: Delete: %w", err)
+	}
+	if ids, err := repo.ListDeleted(ctx, time.Now().Add(-time.Hour)); err != nil || len(ids) != 0 {
+		return fmt.Errorf("movies: ListDeleted before the deletion returned %v, %v", ids, err)
+	}
+	ids, err := repo.ListDeleted(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		return fmt.Errorf("movies: ListDeleted: %w", err)
+	}
+	if len(ids) != 1 || ids[0] != deleted.ID.Hex() {
+		return fmt.Errorf("movies: ListDeleted returned %v, want [%s]", ids, deleted.ID.Hex())
+	}
+	tmdbIDs, err := repo.GetTMDBIDs(ctx, []string{deleted.ID.Hex(), movie.ID.Hex(), missingID})
+	if err != nil {
+		return fmt.Errorf("movies: GetTMDBIDs: %w", err)
+	}
+	if len(tmdbIDs) != 2 || tmdbIDs[deleted.ID.Hex()] != 900002 || tmdbIDs[movie.ID.Hex()] != 900001 {
+		return fmt.Errorf("movies: GetTMDBIDs returned %v, want the deleted and the live movie", tmdbIDs)
+	}
+	purgeIDs := []string{deleted.ID.Hex(), movie.ID.Hex()}
+	if purged, err := repo.Purge(ctx, purgeIDs, time.Now().Add(-time.Hour)); err != nil || len(purged) != 0 {
+		return fmt.Errorf("movies: Purge before the deletion purged %v, %v", purged, err)
+	}
+	purged, err := repo.Purge(ctx, purgeIDs, time.Now().Add(time.Minute))
+	if err != nil {
+		return fmt.Errorf("movies: Purge: %w", err)
+	}
+	if len(purged) != 1 || purged[0] != deleted.ID.Hex() {
+		return fmt.Errorf("movies: Purge returned %v, want [%s]", purged, deleted.ID.Hex())
+	}
+	if err := expectError(repo.Restore(ctx, deleted.ID.Hex()), domain.ErrNotFound, "movies: restoring a purged movie"); err != nil {
+		return err
+	}
+	if _, err := repo.GetByID(ctx, movie.ID.Hex()); err != nil {
+		return fmt.Errorf("movies: Purge removed a live movie: %w", err)
+	}
+
+	return nil
+}
+
+func movieID(m *domain.Movie) primitive.ObjectID { return m.ID }
+
+func movieTMDBIDs(movies []*domain.Movie) []int {
+	ids := make([]int, len(movies))
+	for i, m := range movies {
+		ids[i] = m.TMDBMovieID
+	}
+	return ids
+}
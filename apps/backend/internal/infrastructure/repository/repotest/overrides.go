@@ -0,0 +1,57 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+
+	"backend/internal/domain"
+)
+
+// CheckOverrideRepository checks saving, lookups and deletes of an
+// OverrideRepository.
+func CheckOverrideRepository(ctx context.Context, repo domain.OverrideRepository) error {
+	if override, err := repo.Get(ctx, "movie", 900001); err != nil || override != nil {
+		return fmt.Errorf("overrides: Get of a missing override returned %+v, %v, want nil, nil", override, err)
+	}
+
+	title := "Curated Title"
+	for _, o := range []*domain.CatalogOverride{
+		{MediaType: "movie", TMDBID: 900001, Fields: domain.OverrideFields{Title: &title}},
+		{MediaType: "movie", TMDBID: 900002, Fields: domain.OverrideFields{Title: &title}},
+		{MediaType: "movie", TMDBID: 900003, Fields: domain.OverrideFields{Title: &title}},
+		{MediaType: "tv", TMDBID: 900001, Fields: domain.OverrideFields{Title: &title}},
+	} {
+		if err := repo.Save(ctx, o); err != nil {
+			return fmt.Errorf("overrides: Save: %w", err)
+		}
+		if o.ID.IsZero() {
+			return fmt.Errorf("overrides: Save left the ID unset")
+		}
+	}
+	stored, err := repo.Get(ctx, "movie", 900001)
+	if err != nil || stored == nil || stored.Fields.Title == nil || *stored.Fields.Title != title {
+		return fmt.Errorf("overrides: Get returned %+v, %v", stored, err)
+	}
+
+	if err := repo.Delete(ctx, "movie", 900003); err != nil {
+		return fmt.Errorf("overrides: Delete: %w", err)
+	}
+	if err := repo.DeleteMany(ctx, "movie", []int{900001, 900003, -1}); err != nil {
+		return fmt.Errorf("overrides: DeleteMany: %w", err)
+	}
+	if err := repo.DeleteMany(ctx, "movie", nil); err != nil {
+		return fmt.Errorf("overrides: DeleteMany of nothing: %w", err)
+	}
+	left, err := repo.GetMany(ctx, "movie", []int{900001, 900002, 900003})
+	if err != nil {
+		return fmt.Errorf("overrides: GetMany: %w", err)
+	}
+	if len(left) != 1 || left[0].TMDBID != 900002 {
+		return fmt.Errorf("overrides: GetMany after deletes found %d overrides, want only 900002", len(left))
+	}
+	if tv, err := repo.Get(ctx, "tv", 900001); err != nil || tv == nil {
+		return fmt.Errorf("overrides: DeleteMany of movies removed a TV override: %+v, %v", tv, err)
+	}
+
+	return nil
+}
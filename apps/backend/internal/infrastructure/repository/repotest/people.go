@@ -0,0 +1,61 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+
+	"backend/internal/domain"
+)
+
+// CheckPersonRepository checks lookups and versioned upserts of a
+// PersonRepository.
+func CheckPersonRepository(ctx context.Context, repo domain.PersonRepository) error {
+	_, err := repo.GetByTMDBID(ctx, -1)
+	if err := expectError(err, domain.ErrNotFound, "people: GetByTMDBID of a missing person"); err != nil {
+		return err
+	}
+
+	person := &domain.Person{TMDBPersonID: 900101, Name: "Contract Check", Popularity: 3.5}
+	if err := repo.Upsert(ctx, person); err != nil {
+		return fmt.Errorf("people: Upsert of a new person: %w", err)
+	}
+	if person.ID.IsZero() || person.Version != 1 {
+		return fmt.Errorf("people: Upsert of a new person set ID %s and version %d", person.ID.Hex(), person.Version)
+	}
+
+	same := &domain.Person{TMDBPersonID: 900101, Name: "Contract Check", Popularity: 3.5}
+	if err := repo.Upsert(ctx, same); err != nil {
+		return fmt.Errorf("people: Upsert of an unchanged person: %w", err)
+	}
+	if same.ID != person.ID || same.Version != 1 {
+		return fmt.Errorf("people: Upsert of an unchanged person returned ID %s version %d", same.ID.Hex(), same.Version)
+	}
+
+	changed := &domain.Person{TMDBPersonID: 900101, Name: "Contract Check", Biography: "Now with a biography", Popularity: 4}
+	if err := repo.Upsert(ctx, changed); err != nil {
+		return fmt.Errorf("people: Upsert of a changed person: %w", err)
+	}
+	if changed.ID != person.ID || changed.Version != 2 {
+		return fmt.Errorf("people: Upsert of a changed person returned ID %s version %d, want %s version 2", changed.ID.Hex(), changed.Version, person.ID.Hex())
+	}
+	stored, err := repo.GetByTMDBID(ctx, 900101)
+	if err != nil {
+		return fmt.Errorf("people: GetByTMDBID: %w", err)
+	}
+	if stored.Biography != "Now with a biography" || stored.Version != 2 || !stored.CreatedAt.Equal(person.CreatedAt) {
+		return fmt.Errorf("people: GetByTMDBID returned %+v", stored)
+	}
+
+	if err := repo.Upsert(ctx, &domain.Person{TMDBPersonID: 900102, Name: "Second Person"}); err != nil {
+		return fmt.Errorf("people: Upsert: %w", err)
+	}
+	found, err := repo.GetByTMDBIDs(ctx, []int{900101, 900102, -1})
+	if err != nil {
+		return fmt.Errorf("people: GetByTMDBIDs: %w", err)
+	}
+	if len(found) != 2 {
+		return fmt.Errorf("people: GetByTMDBIDs found %d people, want 2", len(found))
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CheckRatingRepository checks creating, averaging, listing and removing
+// ratings.
+func CheckRatingRepository(ctx context.Context, repo domain.RatingRepository) error {
+	users := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()}
+	movie := primitive.NewObjectID()
+
+	_, err := repo.GetByUserAndItem(ctx, users[0].Hex(), movie.Hex(), "movie")
+	if err := expectError(err, domain.ErrNotFound, "ratings: GetByUserAndItem of a missing rating"); err != nil {
+		return err
+	}
+	if avg, count, err := repo.GetAverageRating(ctx, movie.Hex(), "movie"); err != nil || avg != 0 || count != 0 {
+		return fmt.Errorf("ratings: GetAverageRating of an unrated movie returned %v, %v, %v", avg, count, err)
+	}
+
+	var ratings []*domain.Rating
+	for i, user := range users {
+		rating := &domain.Rating{UserID: user, MovieID: &movie, Type: "movie", Rating: float64(4 + 2*i)}
+		if err := repo.Create(ctx, rating); err != nil {
+			return fmt.Errorf("ratings: Create: %w", err)
+		}
+		ratings = append(ratings, rating)
+	}
+
+	stored, err := repo.GetByUserAndItem(ctx, users[1].Hex(), movie.Hex(), "movie")
+	if err != nil {
+		return fmt.Errorf("ratings: GetByUserAndItem: %w", err)
+	}
+	if stored.ID != ratings[1].ID {
+		return fmt.Errorf("ratings: GetByUserAndItem returned %s, want %s", stored.ID.Hex(), ratings[1].ID.Hex())
+	}
+	stored.Rating = 9
+	stored.Review = "Better the second time"
+	if err := repo.Update(ctx, stored); err != nil {
+		return fmt.Errorf("ratings: Update: %w", err)
+	}
+	if avg, count, err := repo.GetAverageRating(ctx, movie.Hex(), "movie"); err != nil || avg != 7 || count != 3 {
+		return fmt.Errorf("ratings: GetAverageRating returned %v, %v, %v, want 7 over 3", avg, count, err)
+	}
+
+	listed, err := collect(func(page domain.PageRequest) (*domain.Page[*domain.Rating], error) {
+		return repo.GetByItem(ctx, movie.Hex(), "movie", page)
+	}, 2, ratingID)
+	if err != nil {
+		return fmt.Errorf("ratings: GetByItem: %w", err)
+	}
+	if len(listed) != 3 {
+		return fmt.Errorf("ratings: GetByItem listed %d ratings, want 3", len(listed))
+	}
+	byUser, err := repo.GetByUser(ctx, users[2].Hex(), domain.PageRequest{Limit: 10})
+	if err != nil {
+		return fmt.Errorf("ratings: GetByUser: %w", err)
+	}
+	if len(byUser.Items) != 1 || byUser.Items[0].ID != ratings[2].ID {
+		return fmt.Errorf("ratings: GetByUser listed %d ratings, want 1", len(byUser.Items))
+	}
+	if other, err := repo.GetByItem(ctx, movie.Hex(), "tv", domain.PageRequest{Limit: 10}); err != nil || len(other.Items) != 0 {
+		return fmt.Errorf("ratings: GetByItem matched across item types")
+	}
+
+	if err := repo.Delete(ctx, ratings[0].ID.Hex()); err != nil {
+		return fmt.Errorf("ratings: Delete: %w", err)
+	}
+	if err := repo.DeleteByUsers(ctx, []string{users[1].Hex()}); err != nil {
+		return fmt.Errorf("ratings: DeleteByUsers: %w", err)
+	}
+	if avg, count, err := repo.GetAverageRating(ctx, movie.Hex(), "movie"); err != nil || avg != 8 || count != 1 {
+		return fmt.Errorf("ratings: GetAverageRating after deletes returned %v, %v, %v, want 8 over 1", avg, count, err)
+	}
+	if err := repo.DeleteByItems(ctx, []string{movie.Hex()}, "movie"); err != nil {
+		return fmt.Errorf("ratings: DeleteByItems: %w", err)
+	}
+	_, err = repo.GetByUserAndItem(ctx, users[2].Hex(), movie.Hex(), "movie")
+	if err := expectError(err, domain.ErrNotFound, "ratings: GetByUserAndItem after DeleteByItems"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ratingID(r *domain.Rating) primitive.ObjectID { return r.ID }
@@ -0,0 +1,76 @@
+// Package repotest checks that a set of domain repositories honours the
+// contract the use cases rely on: ErrNotFound for missing records, soft
+// deletes, versioned updates and stable keyset paging. Each driver's tests
+// call Run so the drivers can't drift apart. Each check expects empty
+// repositories and leaves data behind.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Run runs every repository check as a subtest, each against repositories
+// freshly made by newRepos.
+func Run(t *testing.T, newRepos func(t *testing.T) domain.Repositories) {
+	ctx := context.Background()
+	run := func(name string, check func(domain.Repositories) error) {
+		t.Run(name, func(t *testing.T) {
+			if err := check(newRepos(t)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	run("movies", func(r domain.Repositories) error { return CheckMovieRepository(ctx, r.Movies) })
+	run("tvshows", func(r domain.Repositories) error { return CheckTVShowRepository(ctx, r.TVShows) })
+	run("people", func(r domain.Repositories) error { return CheckPersonRepository(ctx, r.People) })
+	run("overrides", func(r domain.Repositories) error { return CheckOverrideRepository(ctx, r.Overrides) })
+	run("users", func(r domain.Repositories) error { return CheckUserRepository(ctx, r.Users) })
+	run("watchlists", func(r domain.Repositories) error { return CheckWatchlistRepository(ctx, r.Watchlists) })
+	run("ratings", func(r domain.Repositories) error { return CheckRatingRepository(ctx, r.Ratings) })
+}
+
+// missingID is a well-formed ID no check ever stores.
+var missingID = primitive.NewObjectID().Hex()
+
+func expectError(got, want error, what string) error {
+	if !errors.Is(got, want) {
+		return fmt.Errorf("%s: got error %v, want %v", what, got, want)
+	}
+	return nil
+}
+
+// collect walks every page of a listing, failing on a repeated item or a
+// page longer than the limit.
+func collect[T any](list func(domain.PageRequest) (*domain.Page[T], error), limit int, id func(T) primitive.ObjectID) ([]T, error) {
+	var items []T
+	seen := make(map[primitive.ObjectID]bool)
+	page := domain.PageRequest{Limit: limit}
+	for {
+		result, err := list(page)
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Items) > limit {
+			return nil, fmt.Errorf("page has %d items, limit is %d", len(result.Items), limit)
+		}
+		for _, item := range result.Items {
+			if seen[id(item)] {
+				return nil, fmt.Errorf("item %s listed twice", id(item).Hex())
+			}
+			seen[id(item)] = true
+			items = append(items, item)
+		}
+		if result.NextCursor == "" {
+			return items, nil
+		}
+		page.Cursor = result.NextCursor
+	}
+}
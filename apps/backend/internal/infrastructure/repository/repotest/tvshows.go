@@ -0,0 +1,118 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CheckTVShowRepository checks lookups, versioned updates, upserts, browsing
+// and soft deletes of a TVShowRepository.
+func CheckTVShowRepository(ctx context.Context, repo domain.TVShowRepository) error {
+	_, err := repo.GetByID(ctx, missingID)
+	if err := expectError(err, domain.ErrNotFound, "tv: GetByID of a missing show"); err != nil {
+		return err
+	}
+
+	show := &domain.TVShow{TMDBTVShowID: 900101, Name: "Contract Series", FirstAirDate: "2020-01-01", NumberOfSeasons: 2}
+	if err := repo.Create(ctx, show); err != nil {
+		return fmt.Errorf("tv: Create: %w", err)
+	}
+	if show.ID.IsZero() || show.Version != 1 {
+		return fmt.Errorf("tv: Create set ID %s and version %d, want a new ID and version 1", show.ID.Hex(), show.Version)
+	}
+	stale := *show
+	show.Name = "Contract Series Edited"
+	if err := repo.Update(ctx, show); err != nil {
+		return fmt.Errorf("tv: Update: %w", err)
+	}
+	if err := expectError(repo.Update(ctx, &stale), domain.ErrVersionConflict, "tv: Update with a stale version"); err != nil {
+		return err
+	}
+
+	upserts := []*domain.TVShow{
+		{TMDBTVShowID: 900101, Name: "Contract Series Upstream", FirstAirDate: "2020-01-01"},
+		{TMDBTVShowID: 900102, Name: "Later Series", FirstAirDate: "2023-05-01"},
+		{TMDBTVShowID: 900103, Name: "Middle Series", FirstAirDate: "2021-09-01"},
+	}
+	if err := repo.UpsertMany(ctx, upserts); err != nil {
+		return fmt.Errorf("tv: UpsertMany: %w", err)
+	}
+	if upserts[0].ID != show.ID || upserts[0].Version != 3 {
+		return fmt.Errorf("tv: UpsertMany of a stored show returned ID %s version %d, want %s version 3", upserts[0].ID.Hex(), upserts[0].Version, show.ID.Hex())
+	}
+	stored, err := repo.GetByTMDBID(ctx, 900101)
+	if err != nil {
+		return fmt.Errorf("tv: GetByTMDBID after UpsertMany: %w", err)
+	}
+	if stored.Name != "Contract Series Upstream" || stored.NumberOfSeasons != 2 {
+		return fmt.Errorf("tv: UpsertMany stored %q with %d seasons", stored.Name, stored.NumberOfSeasons)
+	}
+	unchanged := []*domain.TVShow{{TMDBTVShowID: 900101, Name: "Contract Series Upstream", FirstAirDate: "2020-01-01"}}
+	if err := repo.UpsertMany(ctx, unchanged); err != nil {
+		return fmt.Errorf("tv: UpsertMany of an unchanged show: %w", err)
+	}
+	if unchanged[0].Version != 3 {
+		return fmt.Errorf("tv: UpsertMany of an unchanged show moved it to version %d", unchanged[0].Version)
+	}
+
+	listed, err := collect(func(page domain.PageRequest) (*domain.Page[*domain.TVShow], error) {
+		return repo.Browse(ctx, domain.CatalogQuery{Sort: domain.SortByReleaseDate}, page)
+	}, 1, tvShowID)
+	if err != nil {
+		return fmt.Errorf("tv: Browse by release date: %w", err)
+	}
+	if got := tvShowTMDBIDs(listed); fmt.Sprint(got) != "[900102 900103 900101]" {
+		return fmt.Errorf("tv: Browse by release date listed %v", got)
+	}
+
+	if err := repo.Delete(ctx, upserts[1].ID.Hex()); err != nil {
+		return fmt.Errorf("tv: Delete: %w", err)
+	}
+	_, err = repo.GetByTMDBID(ctx, 900102)
+	if err := expectError(err, domain.ErrNotFound, "tv: GetByTMDBID of a deleted show"); err != nil {
+		return err
+	}
+	upserts[1].Name = "Later Series Refreshed"
+	if err := repo.Upsert(ctx, upserts[1]); err != nil {
+		return fmt.Errorf("tv: Upsert of a deleted show: %w", err)
+	}
+	if upserts[1].DeletedAt == nil {
+		return fmt.Errorf("tv: Upsert brought a deleted show back")
+	}
+	list, err := repo.List(ctx, domain.PageRequest{Limit: 10, WithTotal: true})
+	if err != nil {
+		return fmt.Errorf("tv: List: %w", err)
+	}
+	if list.Total == nil || *list.Total != 2 {
+		return fmt.Errorf("tv: List counted a deleted show")
+	}
+	tmdbIDs, err := repo.GetTMDBIDs(ctx, []string{upserts[1].ID.Hex(), show.ID.Hex(), missingID})
+	if err != nil {
+		return fmt.Errorf("tv: GetTMDBIDs: %w", err)
+	}
+	if len(tmdbIDs) != 2 || tmdbIDs[upserts[1].ID.Hex()] != 900102 || tmdbIDs[show.ID.Hex()] != 900101 {
+		return fmt.Errorf("tv: GetTMDBIDs returned %v", tmdbIDs)
+	}
+	if err := repo.Restore(ctx, upserts[1].ID.Hex()); err != nil {
+		return fmt.Errorf("tv: Restore: %w", err)
+	}
+	if _, err := repo.GetByTMDBID(ctx, 900102); err != nil {
+		return fmt.Errorf("tv: GetByTMDBID after Restore: %w", err)
+	}
+
+	return nil
+}
+
+func tvShowID(s *domain.TVShow) primitive.ObjectID { return s.ID }
+
+func tvShowTMDBIDs(shows []*domain.TVShow) []int {
+	ids := make([]int, len(shows))
+	for i, s := range shows {
+		ids[i] = s.TMDBTVShowID
+	}
+	return ids
+}
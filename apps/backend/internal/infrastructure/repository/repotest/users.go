@@ -0,0 +1,95 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CheckUserRepository checks lookups, versioned updates, paging and soft
+// deletes of a UserRepository.
+func CheckUserRepository(ctx context.Context, repo domain.UserRepository) error {
+	_, err := repo.GetByEmail(ctx, "nobody@example.com")
+	if err := expectError(err, domain.ErrNotFound, "users: GetByEmail of a missing user"); err != nil {
+		return err
+	}
+
+	var users []*domain.User
+	for i := 0; i < 5; i++ {
+		user := &domain.User{
+			Email:    fmt.Sprintf("check%d@example.com", i),
+			Username: fmt.Sprintf("check%d", i),
+			IsActive: true,
+		}
+		if err := repo.Create(ctx, user); err != nil {
+			return fmt.Errorf("users: Create: %w", err)
+		}
+		if user.ID.IsZero() || user.Version != 1 {
+			return fmt.Errorf("users: Create set ID %s and version %d, want a new ID and version 1", user.ID.Hex(), user.Version)
+		}
+		users = append(users, user)
+	}
+
+	byName, err := repo.GetByUsername(ctx, "check3")
+	if err != nil {
+		return fmt.Errorf("users: GetByUsername: %w", err)
+	}
+	if byName.ID != users[3].ID {
+		return fmt.Errorf("users: GetByUsername returned %s, want %s", byName.ID.Hex(), users[3].ID.Hex())
+	}
+
+	byName.FirstName = "Checked"
+	if err := repo.Update(ctx, byName); err != nil {
+		return fmt.Errorf("users: Update: %w", err)
+	}
+	users[3].LastName = "Stale"
+	if err := expectError(repo.Update(ctx, users[3]), domain.ErrVersionConflict, "users: Update with a stale version"); err != nil {
+		return err
+	}
+	stored, err := repo.GetByID(ctx, users[3].ID.Hex())
+	if err != nil {
+		return fmt.Errorf("users: GetByID: %w", err)
+	}
+	if stored.FirstName != "Checked" || stored.LastName != "" || stored.Version != 2 {
+		return fmt.Errorf("users: stored user is %q %q version %d", stored.FirstName, stored.LastName, stored.Version)
+	}
+
+	if err := repo.Delete(ctx, users[0].ID.Hex()); err != nil {
+		return fmt.Errorf("users: Delete: %w", err)
+	}
+	_, err = repo.GetByEmail(ctx, users[0].Email)
+	if err := expectError(err, domain.ErrNotFound, "users: GetByEmail of a deleted user"); err != nil {
+		return err
+	}
+	listed, err := collect(func(page domain.PageRequest) (*domain.Page[*domain.User], error) {
+		return repo.List(ctx, page)
+	}, 2, userID)
+	if err != nil {
+		return fmt.Errorf("users: List: %w", err)
+	}
+	if len(listed) != 4 {
+		return fmt.Errorf("users: List returned %d users, want the 4 live ones", len(listed))
+	}
+
+	ids, err := repo.ListDeleted(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		return fmt.Errorf("users: ListDeleted: %w", err)
+	}
+	if len(ids) != 1 || ids[0] != users[0].ID.Hex() {
+		return fmt.Errorf("users: ListDeleted returned %v, want [%s]", ids, users[0].ID.Hex())
+	}
+	if err := repo.Restore(ctx, users[0].ID.Hex()); err != nil {
+		return fmt.Errorf("users: Restore: %w", err)
+	}
+	if _, err := repo.GetByEmail(ctx, users[0].Email); err != nil {
+		return fmt.Errorf("users: GetByEmail after Restore: %w", err)
+	}
+
+	return nil
+}
+
+func userID(u *domain.User) primitive.ObjectID { return u.ID }
@@ -0,0 +1,95 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CheckWatchlistRepository checks adding, listing and removing watchlist
+// entries.
+func CheckWatchlistRepository(ctx context.Context, repo domain.WatchlistRepository) error {
+	user := primitive.NewObjectID()
+	other := primitive.NewObjectID()
+	movies := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()}
+	show := primitive.NewObjectID()
+
+	// Whole milliseconds, the precision MongoDB stores
+	added := time.Now().Truncate(time.Millisecond)
+	var entries []*domain.Watchlist
+	for i := range movies {
+		entries = append(entries, &domain.Watchlist{UserID: user, MovieID: &movies[i], Type: "movie", AddedAt: added.Add(time.Duration(i) * time.Second)})
+	}
+	entries = append(entries,
+		&domain.Watchlist{UserID: user, TVShowID: &show, Type: "tv", AddedAt: added.Add(time.Minute)},
+		&domain.Watchlist{UserID: other, MovieID: &movies[0], Type: "movie", AddedAt: added},
+	)
+	for _, entry := range entries {
+		if err := repo.Add(ctx, entry); err != nil {
+			return fmt.Errorf("watchlist: Add: %w", err)
+		}
+	}
+	if err := repo.Add(ctx, entries[0]); err == nil {
+		return fmt.Errorf("watchlist: adding the same entry twice succeeded")
+	}
+
+	listed, err := collect(func(page domain.PageRequest) (*domain.Page[*domain.Watchlist], error) {
+		return repo.GetByUserID(ctx, user.Hex(), page)
+	}, 3, watchlistID)
+	if err != nil {
+		return fmt.Errorf("watchlist: GetByUserID: %w", err)
+	}
+	want := []primitive.ObjectID{entries[3].ID, entries[2].ID, entries[1].ID, entries[0].ID}
+	if fmt.Sprint(watchlistIDs(listed)) != fmt.Sprint(want) {
+		return fmt.Errorf("watchlist: GetByUserID listed %v, want newest first %v", watchlistIDs(listed), want)
+	}
+
+	if ok, err := repo.IsInWatchlist(ctx, user.Hex(), show.Hex(), "tv"); err != nil || !ok {
+		return fmt.Errorf("watchlist: IsInWatchlist of a listed show returned %v, %v", ok, err)
+	}
+	if ok, err := repo.IsInWatchlist(ctx, user.Hex(), show.Hex(), "movie"); err != nil || ok {
+		return fmt.Errorf("watchlist: IsInWatchlist matched across item types: %v, %v", ok, err)
+	}
+	if _, err := repo.IsInWatchlist(ctx, "not-an-id", show.Hex(), "tv"); err == nil {
+		return fmt.Errorf("watchlist: IsInWatchlist accepted a malformed user ID")
+	}
+
+	if err := repo.Remove(ctx, user.Hex(), movies[1].Hex(), "movie"); err != nil {
+		return fmt.Errorf("watchlist: Remove: %w", err)
+	}
+	if ok, _ := repo.IsInWatchlist(ctx, user.Hex(), movies[1].Hex(), "movie"); ok {
+		return fmt.Errorf("watchlist: entry still listed after Remove")
+	}
+	if err := repo.RemoveByItems(ctx, []string{movies[0].Hex()}, "movie"); err != nil {
+		return fmt.Errorf("watchlist: RemoveByItems: %w", err)
+	}
+	if ok, _ := repo.IsInWatchlist(ctx, other.Hex(), movies[0].Hex(), "movie"); ok {
+		return fmt.Errorf("watchlist: RemoveByItems left another user's entry")
+	}
+	if err := repo.RemoveByUsers(ctx, []string{user.Hex()}); err != nil {
+		return fmt.Errorf("watchlist: RemoveByUsers: %w", err)
+	}
+	page, err := repo.GetByUserID(ctx, user.Hex(), domain.PageRequest{Limit: 10})
+	if err != nil {
+		return fmt.Errorf("watchlist: GetByUserID: %w", err)
+	}
+	if len(page.Items) != 0 {
+		return fmt.Errorf("watchlist: %d entries left after RemoveByUsers", len(page.Items))
+	}
+
+	return nil
+}
+
+func watchlistID(w *domain.Watchlist) primitive.ObjectID { return w.ID }
+
+func watchlistIDs(entries []*domain.Watchlist) []primitive.ObjectID {
+	ids := make([]primitive.ObjectID, len(entries))
+	for i, w := range entries {
+		ids[i] = w.ID
+	}
+	return ids
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"backend/internal/infrastructure/repository/textsearch"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// textSearchFilter builds a $text filter from raw user input. Quotes and
+// leading minus signs are stripped so the input can't turn into phrase or
+// negation operators; what remains is matched as plain terms. It reports
+// false when no terms remain. Hidden and deleted titles never match.
+func textSearchFilter(query string) (bson.M, bool) {
+	terms := textsearch.Terms(query)
+	if len(terms) == 0 {
+		return nil, false
+	}
+	return bson.M{
+		"$text":     bson.M{"$search": strings.Join(terms, " ")},
+		"hidden":    visible,
+		"deletedAt": notDeleted,
+	}, true
+}
+
+// prefixSearchFilter matches titles starting with the query, for partial
+// words the text index can't find. field holds the folded title, so the
+// anchored, case-sensitive regex can walk its index. The input is escaped so
+// regex metacharacters match literally.
+func prefixSearchFilter(field, query string) bson.M {
+	pattern := "^" + regexp.QuoteMeta(textsearch.Fold(query))
+	return bson.M{
+		field:       bson.M{"$regex": pattern},
+		"hidden":    visible,
+		"deletedAt": notDeleted,
+	}
+}
+
+// prefixFindOptions sorts prefix matches on the folded title.
+func prefixFindOptions(field string, limit, offset int) *options.FindOptions {
+	return options.Find().
+		SetSort(bson.D{{Key: field, Value: 1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+}
+
+// findSearchPage loads one page of search results matching filter and counts
+// every match when withTotal is set. It also reports whether anything matched
+// at all, so a page past the last result doesn't trigger the fallback.
+func findSearchPage[T any](ctx context.Context, collection *mongo.Collection, filter bson.M, opts *options.FindOptions, offset int, withTotal bool) ([]T, *int64, bool, error) {
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []T
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, nil, false, err
+	}
+
+	if withTotal {
+		total, err := collection.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		return results, &total, total > 0, nil
+	}
+	if len(results) > 0 || offset == 0 {
+		return results, nil, len(results) > 0, nil
+	}
+	matched, err := collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return results, nil, matched > 0, nil
+}
+
+// noSearchTotal is the total of a search that matched nothing: zero when
+// the total was asked for, nil otherwise.
+func noSearchTotal(withTotal bool) *int64 {
+	if !withTotal {
+		return nil
+	}
+	var total int64
+	return &total
+}
+
+// textScoreFindOptions projects the text score into "score" and sorts the
+// best matches first.
+func textScoreFindOptions(limit, offset int) *options.FindOptions {
+	score := bson.M{"$meta": "textScore"}
+	return options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// notDeleted matches documents that haven't been soft-deleted.
+var notDeleted = bson.M{"$exists": false}
+
+// live restricts filter to documents that haven't been soft-deleted.
+func live(filter bson.M) bson.M {
+	filter["deletedAt"] = notDeleted
+	return filter
+}
+
+// softDelete stamps deletedAt on a live document. It returns
+// domain.ErrNotFound when there is nothing to delete.
+func softDelete(ctx context.Context, collection *mongo.Collection, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := collection.UpdateOne(ctx,
+		live(bson.M{"_id": objectID}),
+		bson.M{"$set": bson.M{"deletedAt": time.Now()}, "$inc": bumpVersion},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// restore clears deletedAt on a soft-deleted document. It returns
+// domain.ErrNotFound when there is nothing to restore.
+func restore(ctx context.Context, collection *mongo.Collection, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": objectID, "deletedAt": bson.M{"$exists": true}},
+		bson.M{
+			"$unset": bson.M{"deletedAt": ""},
+			"$set":   bson.M{"updatedAt": time.Now()},
+			"$inc":   bumpVersion,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// listDeleted returns the IDs of documents soft-deleted before the cutoff.
+func listDeleted(ctx context.Context, collection *mongo.Collection, deletedBefore time.Time) ([]string, error) {
+	filter := bson.M{"deletedAt": bson.M{"$lt": deletedBefore}}
+	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID.Hex())
+	}
+	return ids, nil
+}
+
+// purge hard-deletes the given documents that are still soft-deleted
+// before the cutoff, so a record restored or deleted again in the meantime
+// survives. It returns the IDs no longer stored afterwards.
+func purge(ctx context.Context, collection *mongo.Collection, ids []string, deletedBefore time.Time) ([]string, error) {
+	parsed, err := objectIDs(ids)
+	if err != nil || len(parsed) == 0 {
+		return nil, err
+	}
+
+	if _, err = collection.DeleteMany(ctx, bson.M{
+		"_id":       bson.M{"$in": parsed},
+		"deletedAt": bson.M{"$lt": deletedBefore},
+	}); err != nil {
+		return nil, err
+	}
+
+	cursor, err := collection.Find(ctx, bson.M{"_id": bson.M{"$in": parsed}}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var kept []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err = cursor.All(ctx, &kept); err != nil {
+		return nil, err
+	}
+	stored := make(map[primitive.ObjectID]bool, len(kept))
+	for _, doc := range kept {
+		stored[doc.ID] = true
+	}
+
+	purged := make([]string, 0, len(parsed))
+	for _, id := range parsed {
+		if !stored[id] {
+			purged = append(purged, id.Hex())
+		}
+	}
+	return purged, nil
+}
+
+// tmdbIDsByID maps the given documents, soft-deleted or not, to the TMDB ID
+// held in tmdbIDField.
+func tmdbIDsByID(ctx context.Context, collection *mongo.Collection, tmdbIDField string, ids []string) (map[string]int, error) {
+	parsed, err := objectIDs(ids)
+	if err != nil || len(parsed) == 0 {
+		return nil, err
+	}
+
+	cursor, err := collection.Find(ctx, bson.M{"_id": bson.M{"$in": parsed}},
+		options.Find().SetProjection(bson.M{tmdbIDField: 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	tmdbIDs := make(map[string]int, len(parsed))
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		tmdbID, ok := cursor.Current.Lookup(tmdbIDField).AsInt64OK()
+		if !ok {
+			continue
+		}
+		tmdbIDs[doc.ID.Hex()] = int(tmdbID)
+	}
+	return tmdbIDs, cursor.Err()
+}
+
+// notFound translates the driver's no-documents error into
+// domain.ErrNotFound.
+func notFound(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+// objectIDs parses hex IDs, failing on the first malformed one.
+func objectIDs(ids []string) ([]primitive.ObjectID, error) {
+	parsed := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, objectID)
+	}
+	return parsed, nil
+}
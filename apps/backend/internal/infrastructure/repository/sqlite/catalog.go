@@ -0,0 +1,389 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"backend/internal/domain"
+	"backend/internal/infrastructure/repository/textsearch"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// live and visible match rows that aren't soft-deleted, and on top of that
+// not hidden by an operator.
+const (
+	live    = "deleted_at IS NULL"
+	visible = "deleted_at IS NULL AND hidden = 0"
+)
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// catalogRecord points at the fields of a movie or TV show that the
+// catalog tables keep in columns. The columns, not the JSON document, are
+// authoritative for the bookkeeping fields.
+type catalogRecord struct {
+	ID          *primitive.ObjectID
+	TMDBID      int
+	Title       string
+	ReleaseDate string
+	VoteAverage float64
+	VoteCount   int
+	Hidden      *bool
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+	Version     *int64
+	DeletedAt   **time.Time
+}
+
+// catalogTable stores movies or TV shows; T is *domain.Movie or
+// *domain.TVShow.
+type catalogTable[T any] struct {
+	db      *sql.DB
+	name    string
+	newItem func() T
+	record  func(T) catalogRecord
+	// searchFields are the fields search ranks on, with the weights of the
+	// Mongo text index.
+	searchFields func(T) []textsearch.Field
+}
+
+const catalogColumns = "id, hidden, created_at, updated_at, version, deleted_at, doc"
+
+func (t *catalogTable[T]) scan(row scanner, extra ...interface{}) (T, error) {
+	var (
+		id, createdAt, updatedAt, doc string
+		hidden                        bool
+		version                       int64
+		deletedAt                     sql.NullString
+	)
+	item := t.newItem()
+	dest := append([]interface{}{&id, &hidden, &createdAt, &updatedAt, &version, &deletedAt, &doc}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return item, err
+	}
+	if err := json.Unmarshal([]byte(doc), item); err != nil {
+		return item, err
+	}
+
+	r := t.record(item)
+	var err error
+	if *r.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return item, err
+	}
+	if *r.CreatedAt, err = parseTime(createdAt); err != nil {
+		return item, err
+	}
+	if *r.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return item, err
+	}
+	if *r.DeletedAt, err = parseNullTime(deletedAt); err != nil {
+		return item, err
+	}
+	*r.Hidden = hidden
+	*r.Version = version
+	return item, nil
+}
+
+func (t *catalogTable[T]) getOne(ctx context.Context, where string, args ...interface{}) (T, error) {
+	row := t.db.QueryRowContext(ctx, "SELECT "+catalogColumns+" FROM "+t.name+" WHERE "+live+" AND "+where, args...)
+	item, err := t.scan(row)
+	if err != nil {
+		var zero T
+		return zero, notFound(err)
+	}
+	return item, nil
+}
+
+// getMany returns the rows matching where; rest may add ORDER BY and LIMIT.
+func (t *catalogTable[T]) getMany(ctx context.Context, where, rest string, args ...interface{}) ([]T, error) {
+	rows, err := t.db.QueryContext(ctx, "SELECT "+catalogColumns+" FROM "+t.name+" WHERE "+where+" "+rest, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []T
+	for rows.Next() {
+		item, err := t.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (t *catalogTable[T]) getByTMDBIDs(ctx context.Context, tmdbIDs []int) ([]T, error) {
+	args := make([]interface{}, len(tmdbIDs))
+	for i, id := range tmdbIDs {
+		args[i] = id
+	}
+	return t.getMany(ctx, live+" AND tmdb_id IN ("+placeholders(len(args))+")", "", args...)
+}
+
+// tmdbIDs maps the given rows, soft-deleted or not, to their TMDB IDs.
+func (t *catalogTable[T]) tmdbIDs(ctx context.Context, ids []string) (map[string]int, error) {
+	args, err := parseIDs(ids)
+	if err != nil || len(args) == 0 {
+		return nil, err
+	}
+	rows, err := t.db.QueryContext(ctx, "SELECT id, tmdb_id FROM "+t.name+" WHERE id IN ("+placeholders(len(args))+")", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tmdbIDs := make(map[string]int, len(args))
+	for rows.Next() {
+		var id string
+		var tmdbID int
+		if err := rows.Scan(&id, &tmdbID); err != nil {
+			return nil, err
+		}
+		tmdbIDs[id] = tmdbID
+	}
+	return tmdbIDs, rows.Err()
+}
+
+func (t *catalogTable[T]) create(ctx context.Context, item T) error {
+	r := t.record(item)
+	*r.ID = primitive.NewObjectID()
+	*r.CreatedAt = time.Now()
+	*r.UpdatedAt = time.Now()
+	*r.Version = 1
+
+	doc, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	_, err = t.db.ExecContext(ctx, "INSERT INTO "+t.name+` (id, tmdb_id, title, release_date, vote_average, vote_count,
+		hidden, created_at, updated_at, version, deleted_at, doc) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		r.ID.Hex(), r.TMDBID, r.Title, r.ReleaseDate, r.VoteAverage, r.VoteCount,
+		*r.Hidden, formatTime(*r.CreatedAt), formatTime(*r.UpdatedAt), *r.Version, nullTime(*r.DeletedAt), doc)
+	return err
+}
+
+// upsertMany inserts the items not stored yet, keyed on their TMDB ID, and
+// refreshes the stored ones with apply, all in one transaction. A stored row
+// that apply leaves unchanged isn't written, so its version stays put. The
+// stored ID, CreatedAt, UpdatedAt, Hidden flag, DeletedAt and Version are
+// copied back onto each item. Soft-deleted titles stay deleted.
+func (t *catalogTable[T]) upsertMany(ctx context.Context, items []T, apply func(stored, upstream T)) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, item := range items {
+		r := t.record(item)
+		stored, err := t.scan(tx.QueryRowContext(ctx, "SELECT "+catalogColumns+" FROM "+t.name+" WHERE tmdb_id = ?", r.TMDBID))
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			stored, err = t.insert(ctx, tx, item, now)
+		case err == nil:
+			err = t.refresh(ctx, tx, stored, item, apply, now)
+		}
+		if err != nil {
+			return err
+		}
+
+		s := t.record(stored)
+		*r.ID = *s.ID
+		*r.CreatedAt = *s.CreatedAt
+		*r.UpdatedAt = *s.UpdatedAt
+		*r.Hidden = *s.Hidden
+		*r.DeletedAt = *s.DeletedAt
+		*r.Version = *s.Version
+	}
+
+	return tx.Commit()
+}
+
+// insert stores a copy of item as a new row and returns the copy.
+func (t *catalogTable[T]) insert(ctx context.Context, tx *sql.Tx, item T, now time.Time) (T, error) {
+	stored := t.newItem()
+	doc, err := json.Marshal(item)
+	if err != nil {
+		return stored, err
+	}
+	if err := json.Unmarshal(doc, stored); err != nil {
+		return stored, err
+	}
+
+	r := t.record(stored)
+	*r.ID = primitive.NewObjectID()
+	*r.CreatedAt = now
+	*r.UpdatedAt = now
+	*r.Version = 1
+	*r.Hidden = false
+	*r.DeletedAt = nil
+	if doc, err = json.Marshal(stored); err != nil {
+		return stored, err
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO "+t.name+` (id, tmdb_id, title, release_date, vote_average, vote_count,
+		hidden, created_at, updated_at, version, doc) VALUES (?, ?, ?, ?, ?, ?, 0, ?, ?, 1, ?)`,
+		r.ID.Hex(), r.TMDBID, r.Title, r.ReleaseDate, r.VoteAverage, r.VoteCount,
+		formatTime(now), formatTime(now), doc)
+	return stored, err
+}
+
+// refresh applies upstream to the stored row and writes it back if that
+// changed anything.
+func (t *catalogTable[T]) refresh(ctx context.Context, tx *sql.Tx, stored, upstream T, apply func(stored, upstream T), now time.Time) error {
+	before, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	apply(stored, upstream)
+	doc, err := json.Marshal(stored)
+	if err != nil || bytes.Equal(before, doc) {
+		return err
+	}
+
+	r := t.record(stored)
+	*r.UpdatedAt = now
+	*r.Version++
+	if doc, err = json.Marshal(stored); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE "+t.name+` SET title = ?, release_date = ?, vote_average = ?, vote_count = ?,
+		updated_at = ?, version = ?, doc = ? WHERE id = ?`,
+		r.Title, r.ReleaseDate, r.VoteAverage, r.VoteCount, formatTime(now), *r.Version, doc, r.ID.Hex())
+	return err
+}
+
+func (t *catalogTable[T]) update(ctx context.Context, item T) error {
+	r := t.record(item)
+	*r.UpdatedAt = time.Now()
+	*r.Version++
+
+	doc, err := json.Marshal(item)
+	if err != nil {
+		*r.Version--
+		return err
+	}
+	res, err := t.db.ExecContext(ctx, "UPDATE "+t.name+` SET tmdb_id = ?, title = ?, release_date = ?, vote_average = ?,
+		vote_count = ?, hidden = ?, created_at = ?, updated_at = ?, version = ?, doc = ?
+		WHERE id = ? AND `+live+` AND version = ?`,
+		r.TMDBID, r.Title, r.ReleaseDate, r.VoteAverage, r.VoteCount, *r.Hidden,
+		formatTime(*r.CreatedAt), formatTime(*r.UpdatedAt), *r.Version, doc,
+		r.ID.Hex(), *r.Version-1)
+	if err == nil {
+		err = versionedResult(ctx, t.db, t.name, r.ID.Hex(), res)
+	}
+	if err != nil {
+		*r.Version--
+		return err
+	}
+	return nil
+}
+
+func (t *catalogTable[T]) browse(ctx context.Context, query domain.CatalogQuery, page domain.PageRequest) (*domain.Page[T], error) {
+	where := visible
+	var args []interface{}
+	if query.GenreID != 0 {
+		where += " AND EXISTS (SELECT 1 FROM json_each(doc, '$.genres') WHERE json_extract(value, '$.id') = ?)"
+		args = append(args, query.GenreID)
+	}
+
+	return findPage(ctx, t.db, pageQuery[T]{
+		table:   t.name,
+		columns: catalogColumns,
+		where:   where,
+		args:    args,
+		order:   catalogListing(query.Sort),
+		scan: func(rows *sql.Rows, key ...interface{}) (T, error) {
+			return t.scan(rows, key...)
+		},
+	}, page)
+}
+
+// search ranks visible items by the weighted text score. When nothing
+// scores, it falls back to a title prefix match, unscored.
+func (t *catalogTable[T]) search(ctx context.Context, query string, limit, offset int, withTotal bool) ([]textsearch.Match[T], *int64, error) {
+	terms := textsearch.Terms(query)
+	if len(terms) == 0 {
+		return nil, searchTotal(0, withTotal), nil
+	}
+
+	// The LIKE filter only narrows the candidates down; scoring decides
+	// what matches.
+	likes := make([]string, len(terms))
+	args := make([]interface{}, len(terms))
+	for i, term := range terms {
+		likes[i] = `doc LIKE ? ESCAPE '\'`
+		args[i] = "%" + escapeLike(term) + "%"
+	}
+	candidates, err := t.getMany(ctx, visible+" AND ("+strings.Join(likes, " OR ")+")", "", args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	var matches []textsearch.Match[T]
+	for _, item := range candidates {
+		if score := textsearch.Score(terms, t.searchFields(item)...); score > 0 {
+			matches = append(matches, textsearch.Match[T]{Item: item, Score: score})
+		}
+	}
+	if len(matches) > 0 {
+		textsearch.Rank(matches, func(item T) string { return t.record(item).ID.Hex() })
+		return textsearch.Window(matches, limit, offset), searchTotal(len(matches), withTotal), nil
+	}
+
+	prefix := escapeLike(strings.TrimSpace(query)) + "%"
+	var total *int64
+	if withTotal {
+		total = new(int64)
+		if err := t.db.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM "+t.name+" WHERE "+visible+` AND title LIKE ? ESCAPE '\'`, prefix,
+		).Scan(total); err != nil {
+			return nil, nil, err
+		}
+	}
+	items, err := t.getMany(ctx, visible+` AND title LIKE ? ESCAPE '\'`, "ORDER BY title LIMIT ? OFFSET ?", prefix, limit, offset)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, item := range items {
+		matches = append(matches, textsearch.Match[T]{Item: item})
+	}
+	return matches, total, nil
+}
+
+func (t *catalogTable[T]) listStale(ctx context.Context, updatedBefore time.Time, after domain.RefreshPosition, limit int) ([]T, error) {
+	where := live + " AND updated_at < ?"
+	args := []interface{}{formatTime(updatedBefore)}
+	if !after.ID.IsZero() {
+		where += " AND (updated_at > ? OR (updated_at = ? AND id > ?))"
+		at := formatTime(after.UpdatedAt)
+		args = append(args, at, at, after.ID.Hex())
+	}
+	return t.getMany(ctx, where, "ORDER BY updated_at, id LIMIT ?", append(args, limit)...)
+}
+
+// searchTotal returns the match count of a search when it was asked for.
+func searchTotal(matches int, withTotal bool) *int64 {
+	if !withTotal {
+		return nil
+	}
+	total := int64(matches)
+	return &total
+}
+
+// escapeLike escapes LIKE wildcards so s matches literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"backend/internal/domain"
+)
+
+type checkpointRepository struct {
+	db *sql.DB
+}
+
+func NewCheckpointRepository(db *sql.DB) domain.CheckpointRepository {
+	return &checkpointRepository{db: db}
+}
+
+func (r *checkpointRepository) Get(ctx context.Context, name string) (*domain.Checkpoint, error) {
+	checkpoint := domain.Checkpoint{Name: name}
+	var syncedThrough, updatedAt string
+	err := r.db.QueryRowContext(ctx,
+		`SELECT synced_through, "offset", updated_at FROM checkpoints WHERE name = ?`, name,
+	).Scan(&syncedThrough, &checkpoint.Offset, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if checkpoint.SyncedThrough, err = parseTime(syncedThrough); err != nil {
+		return nil, err
+	}
+	if checkpoint.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+func (r *checkpointRepository) Save(ctx context.Context, checkpoint *domain.Checkpoint) error {
+	checkpoint.UpdatedAt = time.Now()
+
+	_, err := r.db.ExecContext(ctx, `INSERT INTO checkpoints (name, synced_through, "offset", updated_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (name) DO UPDATE SET synced_through = excluded.synced_through, "offset" = excluded."offset",
+			updated_at = excluded.updated_at`,
+		checkpoint.Name, formatTime(checkpoint.SyncedThrough), checkpoint.Offset, formatTime(checkpoint.UpdatedAt))
+	return err
+}
@@ -0,0 +1,8 @@
+//go:build cgo
+
+package sqlite
+
+import _ "github.com/mattn/go-sqlite3"
+
+// driverName is the database/sql driver Open uses.
+const driverName = "sqlite3"
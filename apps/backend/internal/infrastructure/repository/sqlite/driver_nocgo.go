@@ -0,0 +1,7 @@
+//go:build !cgo
+
+package sqlite
+
+// driverName is empty in builds without cgo, which can't compile the SQLite
+// driver; Open then fails with errNoCGO.
+const driverName = ""
@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Watchlist entries and ratings point at a movie or a TV show depending on
+// their type; both tables keep the target in a single item_id column.
+
+// itemColumn returns the item_id value for an entry of itemType.
+func itemColumn(itemType string, movieID, tvShowID *primitive.ObjectID) interface{} {
+	ref := movieID
+	if itemType == "tv" {
+		ref = tvShowID
+	}
+	if ref == nil {
+		return nil
+	}
+	return ref.Hex()
+}
+
+// setItem fills in MovieID or TVShowID from a scanned item_id.
+func setItem(itemType string, itemID sql.NullString, movieID, tvShowID **primitive.ObjectID) error {
+	if !itemID.Valid {
+		return nil
+	}
+	id, err := primitive.ObjectIDFromHex(itemID.String)
+	if err != nil {
+		return err
+	}
+	if itemType == "tv" {
+		*tvShowID = &id
+	} else {
+		*movieID = &id
+	}
+	return nil
+}
+
+// userItemArgs validates the IDs of a per-user item lookup.
+func userItemArgs(userID, itemID string) (string, string, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return "", "", err
+	}
+	itemObjectID, err := primitive.ObjectIDFromHex(itemID)
+	if err != nil {
+		return "", "", err
+	}
+	return userObjectID.Hex(), itemObjectID.Hex(), nil
+}
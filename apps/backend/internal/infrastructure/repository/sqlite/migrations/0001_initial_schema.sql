@@ -0,0 +1,98 @@
+-- Movies and TV shows keep the full record as JSON in doc; the other
+-- columns are what listings filter and sort on.
+CREATE TABLE movies (
+    id           TEXT PRIMARY KEY,
+    tmdb_id      INTEGER NOT NULL UNIQUE,
+    title        TEXT NOT NULL,
+    release_date TEXT NOT NULL,
+    vote_average REAL NOT NULL,
+    vote_count   INTEGER NOT NULL,
+    hidden       INTEGER NOT NULL DEFAULT 0,
+    created_at   TEXT NOT NULL,
+    updated_at   TEXT NOT NULL,
+    version      INTEGER NOT NULL,
+    deleted_at   TEXT,
+    doc          TEXT NOT NULL
+);
+CREATE INDEX movies_vote ON movies (vote_average DESC, vote_count DESC, id);
+CREATE INDEX movies_release_date ON movies (release_date DESC, id);
+CREATE INDEX movies_created_at ON movies (created_at DESC, id);
+CREATE INDEX movies_updated_at ON movies (updated_at, id);
+CREATE INDEX movies_deleted_at ON movies (deleted_at) WHERE deleted_at IS NOT NULL;
+
+CREATE TABLE tv_shows (
+    id           TEXT PRIMARY KEY,
+    tmdb_id      INTEGER NOT NULL UNIQUE,
+    title        TEXT NOT NULL,
+    release_date TEXT NOT NULL,
+    vote_average REAL NOT NULL,
+    vote_count   INTEGER NOT NULL,
+    hidden       INTEGER NOT NULL DEFAULT 0,
+    created_at   TEXT NOT NULL,
+    updated_at   TEXT NOT NULL,
+    version      INTEGER NOT NULL,
+    deleted_at   TEXT,
+    doc          TEXT NOT NULL
+);
+CREATE INDEX tv_shows_vote ON tv_shows (vote_average DESC, vote_count DESC, id);
+CREATE INDEX tv_shows_release_date ON tv_shows (release_date DESC, id);
+CREATE INDEX tv_shows_created_at ON tv_shows (created_at DESC, id);
+CREATE INDEX tv_shows_updated_at ON tv_shows (updated_at, id);
+CREATE INDEX tv_shows_deleted_at ON tv_shows (deleted_at) WHERE deleted_at IS NOT NULL;
+
+CREATE TABLE users (
+    id         TEXT PRIMARY KEY,
+    email      TEXT NOT NULL,
+    username   TEXT NOT NULL,
+    first_name TEXT NOT NULL,
+    last_name  TEXT NOT NULL,
+    avatar     TEXT NOT NULL,
+    is_active  INTEGER NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    version    INTEGER NOT NULL,
+    deleted_at TEXT
+);
+CREATE INDEX users_email ON users (email);
+CREATE INDEX users_username ON users (username);
+CREATE INDEX users_deleted_at ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- item_id is the movie or TV show ID, depending on type
+CREATE TABLE watchlists (
+    id       TEXT PRIMARY KEY,
+    user_id  TEXT NOT NULL,
+    type     TEXT NOT NULL,
+    item_id  TEXT,
+    added_at TEXT NOT NULL
+);
+CREATE INDEX watchlists_user ON watchlists (user_id, added_at DESC, id);
+CREATE INDEX watchlists_item ON watchlists (type, item_id);
+
+CREATE TABLE ratings (
+    id         TEXT PRIMARY KEY,
+    user_id    TEXT NOT NULL,
+    type       TEXT NOT NULL,
+    item_id    TEXT,
+    rating     REAL NOT NULL,
+    review     TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+CREATE INDEX ratings_user ON ratings (user_id, created_at DESC, id);
+CREATE INDEX ratings_item ON ratings (type, item_id, created_at DESC, id);
+
+CREATE TABLE catalog_overrides (
+    id         TEXT NOT NULL UNIQUE,
+    media_type TEXT NOT NULL,
+    tmdb_id    INTEGER NOT NULL,
+    fields     TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    PRIMARY KEY (media_type, tmdb_id)
+);
+
+CREATE TABLE checkpoints (
+    name           TEXT PRIMARY KEY,
+    synced_through TEXT NOT NULL,
+    "offset"       INTEGER NOT NULL,
+    updated_at     TEXT NOT NULL
+);
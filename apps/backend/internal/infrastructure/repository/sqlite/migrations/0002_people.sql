@@ -0,0 +1,9 @@
+-- People keep the full record as JSON in doc, like movies and TV shows
+CREATE TABLE people (
+    id         TEXT PRIMARY KEY,
+    tmdb_id    INTEGER NOT NULL UNIQUE,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    version    INTEGER NOT NULL,
+    doc        TEXT NOT NULL
+);
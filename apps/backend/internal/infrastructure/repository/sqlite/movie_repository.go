@@ -0,0 +1,140 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"backend/internal/domain"
+	"backend/internal/infrastructure/repository/textsearch"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type movieRepository struct {
+	table *catalogTable[*domain.Movie]
+}
+
+func NewMovieRepository(db *sql.DB) domain.MovieRepository {
+	return &movieRepository{
+		table: &catalogTable[*domain.Movie]{
+			db:      db,
+			name:    "movies",
+			newItem: func() *domain.Movie { return &domain.Movie{} },
+			record: func(m *domain.Movie) catalogRecord {
+				return catalogRecord{
+					ID:          &m.ID,
+					TMDBID:      m.TMDBMovieID,
+					Title:       m.Title,
+					ReleaseDate: m.ReleaseDate,
+					VoteAverage: m.VoteAverage,
+					VoteCount:   m.VoteCount,
+					Hidden:      &m.Hidden,
+					CreatedAt:   &m.CreatedAt,
+					UpdatedAt:   &m.UpdatedAt,
+					Version:     &m.Version,
+					DeletedAt:   &m.DeletedAt,
+				}
+			},
+			searchFields: func(m *domain.Movie) []textsearch.Field {
+				return []textsearch.Field{
+					{Text: m.Title, Weight: 10},
+					{Text: m.Tagline, Weight: 4},
+					{Text: m.Overview, Weight: 2},
+				}
+			},
+		},
+	}
+}
+
+func (r *movieRepository) GetByID(ctx context.Context, id string) (*domain.Movie, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.table.getOne(ctx, "id = ?", objectID.Hex())
+}
+
+func (r *movieRepository) GetByTMDBID(ctx context.Context, tmdbID int) (*domain.Movie, error) {
+	return r.table.getOne(ctx, "tmdb_id = ?", tmdbID)
+}
+
+func (r *movieRepository) GetByTMDBIDs(ctx context.Context, tmdbIDs []int) ([]*domain.Movie, error) {
+	return r.table.getByTMDBIDs(ctx, tmdbIDs)
+}
+
+func (r *movieRepository) GetTMDBIDs(ctx context.Context, ids []string) (map[string]int, error) {
+	return r.table.tmdbIDs(ctx, ids)
+}
+
+func (r *movieRepository) Create(ctx context.Context, movie *domain.Movie) error {
+	return r.table.create(ctx, movie)
+}
+
+// Upsert stores a movie fetched in full from TMDB, keyed on its TMDB ID.
+func (r *movieRepository) Upsert(ctx context.Context, movie *domain.Movie) error {
+	return r.table.upsertMany(ctx, []*domain.Movie{movie}, (*domain.Movie).ApplyUpstream)
+}
+
+// UpsertMany stores movies from a TMDB list result. Stored movies only get
+// the fields list results carry refreshed; new ones are inserted whole.
+func (r *movieRepository) UpsertMany(ctx context.Context, movies []*domain.Movie) error {
+	return r.table.upsertMany(ctx, movies, (*domain.Movie).ApplyListing)
+}
+
+func (r *movieRepository) Update(ctx context.Context, movie *domain.Movie) error {
+	return r.table.update(ctx, movie)
+}
+
+// Delete soft-deletes a movie; it stays stored until purged.
+func (r *movieRepository) Delete(ctx context.Context, id string) error {
+	return softDelete(ctx, r.table.db, r.table.name, id)
+}
+
+func (r *movieRepository) Restore(ctx context.Context, id string) error {
+	return restore(ctx, r.table.db, r.table.name, id)
+}
+
+func (r *movieRepository) ListDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	return listDeleted(ctx, r.table.db, r.table.name, deletedBefore)
+}
+
+func (r *movieRepository) Purge(ctx context.Context, ids []string, deletedBefore time.Time) ([]string, error) {
+	return purge(ctx, r.table.db, r.table.name, ids, deletedBefore)
+}
+
+func (r *movieRepository) List(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.Movie], error) {
+	return r.Browse(ctx, domain.CatalogQuery{}, page)
+}
+
+// Search ranks movies by title, tagline and overview with the weights of
+// the Mongo text index, falling back to a title prefix match.
+func (r *movieRepository) Search(ctx context.Context, query string, limit, offset int, withTotal bool) ([]*domain.MovieSearchResult, *int64, error) {
+	matches, total, err := r.table.search(ctx, query, limit, offset, withTotal)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := []*domain.MovieSearchResult{}
+	for _, match := range matches {
+		results = append(results, &domain.MovieSearchResult{Movie: *match.Item, Score: match.Score})
+	}
+	return results, total, nil
+}
+
+func (r *movieRepository) GetByGenre(ctx context.Context, genreID int, page domain.PageRequest) (*domain.Page[*domain.Movie], error) {
+	return r.Browse(ctx, domain.CatalogQuery{GenreID: genreID}, page)
+}
+
+func (r *movieRepository) GetPopular(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.Movie], error) {
+	return r.Browse(ctx, domain.CatalogQuery{Sort: domain.SortByVote}, page)
+}
+
+// Browse lists one page of stored movies matching the query.
+func (r *movieRepository) Browse(ctx context.Context, query domain.CatalogQuery, page domain.PageRequest) (*domain.Page[*domain.Movie], error) {
+	return r.table.browse(ctx, query, page)
+}
+
+func (r *movieRepository) ListStale(ctx context.Context, updatedBefore time.Time, after domain.RefreshPosition, limit int) ([]*domain.Movie, error) {
+	return r.table.listStale(ctx, updatedBefore, after, limit)
+}
@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type overrideRepository struct {
+	db *sql.DB
+}
+
+func NewOverrideRepository(db *sql.DB) domain.OverrideRepository {
+	return &overrideRepository{db: db}
+}
+
+const overrideColumns = "id, media_type, tmdb_id, fields, updated_at"
+
+func scanOverride(row scanner) (*domain.CatalogOverride, error) {
+	var override domain.CatalogOverride
+	var id, fields, updatedAt string
+	if err := row.Scan(&id, &override.MediaType, &override.TMDBID, &fields, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if override.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	if override.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(fields), &override.Fields); err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
+func (r *overrideRepository) Get(ctx context.Context, mediaType string, tmdbID int) (*domain.CatalogOverride, error) {
+	row := r.db.QueryRowContext(ctx,
+		"SELECT "+overrideColumns+" FROM catalog_overrides WHERE media_type = ? AND tmdb_id = ?",
+		mediaType, tmdbID)
+	override, err := scanOverride(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return override, err
+}
+
+func (r *overrideRepository) GetMany(ctx context.Context, mediaType string, tmdbIDs []int) ([]*domain.CatalogOverride, error) {
+	args := []interface{}{mediaType}
+	for _, id := range tmdbIDs {
+		args = append(args, id)
+	}
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT "+overrideColumns+" FROM catalog_overrides WHERE media_type = ? AND tmdb_id IN ("+placeholders(len(tmdbIDs))+")",
+		args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var overrides []*domain.CatalogOverride
+	for rows.Next() {
+		override, err := scanOverride(rows)
+		if err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, override)
+	}
+	return overrides, rows.Err()
+}
+
+// Save replaces the stored override for the same media type and TMDB ID,
+// creating it when missing.
+func (r *overrideRepository) Save(ctx context.Context, override *domain.CatalogOverride) error {
+	override.UpdatedAt = time.Now()
+	fields, err := json.Marshal(override.Fields)
+	if err != nil {
+		return err
+	}
+
+	var id string
+	err = r.db.QueryRowContext(ctx, `INSERT INTO catalog_overrides (`+overrideColumns+`) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (media_type, tmdb_id) DO UPDATE SET fields = excluded.fields, updated_at = excluded.updated_at
+		RETURNING id`,
+		primitive.NewObjectID().Hex(), override.MediaType, override.TMDBID, fields, formatTime(override.UpdatedAt),
+	).Scan(&id)
+	if err != nil {
+		return err
+	}
+
+	override.ID, err = primitive.ObjectIDFromHex(id)
+	return err
+}
+
+func (r *overrideRepository) Delete(ctx context.Context, mediaType string, tmdbID int) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM catalog_overrides WHERE media_type = ? AND tmdb_id = ?", mediaType, tmdbID)
+	return err
+}
+
+func (r *overrideRepository) DeleteMany(ctx context.Context, mediaType string, tmdbIDs []int) error {
+	if len(tmdbIDs) == 0 {
+		return nil
+	}
+	args := make([]interface{}, 0, len(tmdbIDs)+1)
+	args = append(args, mediaType)
+	for _, id := range tmdbIDs {
+		args = append(args, id)
+	}
+	_, err := r.db.ExecContext(ctx,
+		"DELETE FROM catalog_overrides WHERE media_type = ? AND tmdb_id IN ("+placeholders(len(tmdbIDs))+")", args...)
+	return err
+}
@@ -0,0 +1,217 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"backend/internal/domain"
+)
+
+const defaultPageLimit = 20
+
+// sortColumn is one column of a listing order.
+type sortColumn struct {
+	name string
+	desc bool
+}
+
+// listing is one sort order. Like the Mongo sorts, the last column must be
+// unique (normally id) so every row has a distinct position.
+type listing struct {
+	// name identifies the sort; cursors issued for another one are rejected.
+	name    string
+	columns []sortColumn
+}
+
+var (
+	byID        = listing{"id", []sortColumn{{"id", false}}}
+	newestFirst = func(column string) listing {
+		return listing{"newest", []sortColumn{{column, true}, {"id", false}}}
+	}
+)
+
+// catalogListing mirrors the Mongo catalogSort orders.
+func catalogListing(sort domain.CatalogSort) listing {
+	switch sort {
+	case domain.SortByVote:
+		return listing{"vote", []sortColumn{{"vote_average", true}, {"vote_count", true}, {"id", false}}}
+	case domain.SortByReleaseDate:
+		return listing{"release_date", []sortColumn{{"release_date", true}, {"id", false}}}
+	case domain.SortByRecent:
+		return listing{"recent", []sortColumn{{"created_at", true}, {"id", false}}}
+	}
+	return byID
+}
+
+func (l listing) orderBy() string {
+	parts := make([]string, len(l.columns))
+	for i, c := range l.columns {
+		parts[i] = c.name
+		if c.desc {
+			parts[i] += " DESC"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (l listing) keyColumns() string {
+	names := make([]string, len(l.columns))
+	for i, c := range l.columns {
+		names[i] = c.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// after matches rows positioned strictly after key in this order.
+func (l listing) after(key []interface{}) (string, []interface{}) {
+	var branches []string
+	var args []interface{}
+	for i, c := range l.columns {
+		var conds []string
+		for j := 0; j < i; j++ {
+			conds = append(conds, l.columns[j].name+" = ?")
+			args = append(args, key[j])
+		}
+		op := " > ?"
+		if c.desc {
+			op = " < ?"
+		}
+		conds = append(conds, c.name+op)
+		args = append(args, key[i])
+		branches = append(branches, "("+strings.Join(conds, " AND ")+")")
+	}
+	return "(" + strings.Join(branches, " OR ") + ")", args
+}
+
+type cursorPayload struct {
+	Listing string        `json:"l"`
+	Key     []interface{} `json:"k"`
+}
+
+func (l listing) encodeCursor(key []interface{}) string {
+	encoded, _ := json.Marshal(cursorPayload{Listing: l.name, Key: key})
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// decodeCursor unpacks a cursor and checks it was issued for this listing.
+func (l listing) decodeCursor(cursor string) ([]interface{}, error) {
+	encoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(encoded, &payload); err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	if payload.Listing != l.name || len(payload.Key) != len(l.columns) {
+		return nil, domain.ErrInvalidCursor
+	}
+	for _, value := range payload.Key {
+		switch value.(type) {
+		case float64, string:
+		default:
+			return nil, domain.ErrInvalidCursor
+		}
+	}
+
+	return payload.Key, nil
+}
+
+// pageQuery selects one page of rows from a table.
+type pageQuery[T any] struct {
+	table   string
+	columns string
+	where   string
+	args    []interface{}
+	order   listing
+	// scan reads the row's columns, then the sort key columns into key.
+	scan func(rows *sql.Rows, key ...interface{}) (T, error)
+}
+
+// findPage returns one page of rows in listing order, starting after the
+// cursor position.
+func findPage[T any](ctx context.Context, db *sql.DB, q pageQuery[T], page domain.PageRequest) (*domain.Page[T], error) {
+	if page.Limit <= 0 {
+		page.Limit = defaultPageLimit
+	}
+
+	where, args := q.where, append([]interface{}{}, q.args...)
+	if page.Cursor != "" {
+		key, err := q.order.decodeCursor(page.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		after, afterArgs := q.order.after(key)
+		where += " AND " + after
+		args = append(args, afterArgs...)
+	}
+
+	rows, err := db.QueryContext(ctx,
+		"SELECT "+q.columns+", "+q.order.keyColumns()+" FROM "+q.table+
+			" WHERE "+where+" ORDER BY "+q.order.orderBy()+" LIMIT ?",
+		append(args, page.Limit+1)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []T{}
+	var lastKey []interface{}
+	hasMore := false
+	for rows.Next() {
+		if len(items) == page.Limit {
+			hasMore = true
+			break
+		}
+		key := make([]interface{}, len(q.order.columns))
+		dest := make([]interface{}, len(key))
+		for i := range key {
+			dest[i] = &key[i]
+		}
+		item, err := q.scan(rows, dest...)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		lastKey = key
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := &domain.Page[T]{Items: items}
+	if hasMore {
+		result.NextCursor = q.order.encodeCursor(cursorKey(lastKey))
+	}
+
+	if page.WithTotal {
+		var total int64
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+q.table+" WHERE "+q.where, q.args...).Scan(&total); err != nil {
+			return nil, err
+		}
+		result.Total = &total
+	}
+
+	return result, nil
+}
+
+// cursorKey converts scanned sort values to the float64 and string forms
+// that survive the JSON round trip.
+func cursorKey(key []interface{}) []interface{} {
+	out := make([]interface{}, len(key))
+	for i, value := range key {
+		switch v := value.(type) {
+		case int64:
+			out[i] = float64(v)
+		case []byte:
+			out[i] = string(v)
+		default:
+			out[i] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,141 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type personRepository struct {
+	db *sql.DB
+}
+
+func NewPersonRepository(db *sql.DB) domain.PersonRepository {
+	return &personRepository{db: db}
+}
+
+const personColumns = "id, created_at, updated_at, version, doc"
+
+// scanPerson reads a row of personColumns. The columns, not the JSON
+// document, are authoritative for the bookkeeping fields.
+func scanPerson(row scanner) (*domain.Person, error) {
+	var id, createdAt, updatedAt, doc string
+	var version int64
+	if err := row.Scan(&id, &createdAt, &updatedAt, &version, &doc); err != nil {
+		return nil, err
+	}
+
+	var person domain.Person
+	if err := json.Unmarshal([]byte(doc), &person); err != nil {
+		return nil, err
+	}
+	var err error
+	if person.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	if person.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if person.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	person.Version = version
+	return &person, nil
+}
+
+func (r *personRepository) GetByTMDBID(ctx context.Context, tmdbID int) (*domain.Person, error) {
+	person, err := scanPerson(r.db.QueryRowContext(ctx,
+		`SELECT `+personColumns+` FROM people WHERE tmdb_id = ?`, tmdbID))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return person, nil
+}
+
+func (r *personRepository) GetByTMDBIDs(ctx context.Context, tmdbIDs []int) ([]*domain.Person, error) {
+	args := make([]interface{}, len(tmdbIDs))
+	for i, id := range tmdbIDs {
+		args[i] = id
+	}
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+personColumns+` FROM people WHERE tmdb_id IN (`+placeholders(len(args))+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var people []*domain.Person
+	for rows.Next() {
+		person, err := scanPerson(rows)
+		if err != nil {
+			return nil, err
+		}
+		people = append(people, person)
+	}
+	return people, rows.Err()
+}
+
+// Upsert inserts the person when it isn't stored yet and otherwise writes
+// it over the stored one when anything changed, in one transaction.
+func (r *personRepository) Upsert(ctx context.Context, person *domain.Person) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stored, err := scanPerson(tx.QueryRowContext(ctx,
+		`SELECT `+personColumns+` FROM people WHERE tmdb_id = ?`, person.TMDBPersonID))
+	if errors.Is(err, sql.ErrNoRows) {
+		now := time.Now()
+		person.ID = primitive.NewObjectID()
+		person.CreatedAt = now
+		person.UpdatedAt = now
+		person.Version = 1
+
+		doc, err := json.Marshal(person)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO people (`+personColumns+`, tmdb_id) VALUES (?, ?, ?, ?, ?, ?)`,
+			person.ID.Hex(), formatTime(now), formatTime(now), person.Version, string(doc), person.TMDBPersonID,
+		); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+	if err != nil {
+		return err
+	}
+
+	person.ID = stored.ID
+	person.CreatedAt = stored.CreatedAt
+	person.UpdatedAt = stored.UpdatedAt
+	person.Version = stored.Version
+	if reflect.DeepEqual(person, stored) {
+		return nil
+	}
+
+	person.UpdatedAt = time.Now()
+	person.Version++
+	doc, err := json.Marshal(person)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE people SET updated_at = ?, version = ?, doc = ? WHERE id = ?`,
+		formatTime(person.UpdatedAt), person.Version, string(doc), person.ID.Hex(),
+	); err != nil {
+		person.Version--
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,165 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ratingRepository struct {
+	db *sql.DB
+}
+
+func NewRatingRepository(db *sql.DB) domain.RatingRepository {
+	return &ratingRepository{db: db}
+}
+
+const ratingColumns = "id, user_id, type, item_id, rating, review, created_at, updated_at"
+
+func scanRating(row scanner, extra ...interface{}) (*domain.Rating, error) {
+	var rating domain.Rating
+	var id, userID, createdAt, updatedAt string
+	var itemID sql.NullString
+	dest := append([]interface{}{&id, &userID, &rating.Type, &itemID, &rating.Rating, &rating.Review, &createdAt, &updatedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if rating.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	if rating.UserID, err = primitive.ObjectIDFromHex(userID); err != nil {
+		return nil, err
+	}
+	if rating.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if rating.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	if err := setItem(rating.Type, itemID, &rating.MovieID, &rating.TVShowID); err != nil {
+		return nil, err
+	}
+	return &rating, nil
+}
+
+func (r *ratingRepository) GetByUserAndItem(ctx context.Context, userID, itemID string, itemType string) (*domain.Rating, error) {
+	user, item, err := userItemArgs(userID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.db.QueryRowContext(ctx,
+		"SELECT "+ratingColumns+" FROM ratings WHERE user_id = ? AND type = ? AND item_id = ? LIMIT 1",
+		user, itemType, item)
+	rating, err := scanRating(row)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return rating, nil
+}
+
+// GetByItem lists the ratings of one movie or TV show, newest first.
+func (r *ratingRepository) GetByItem(ctx context.Context, itemID string, itemType string, page domain.PageRequest) (*domain.Page[*domain.Rating], error) {
+	itemObjectID, err := primitive.ObjectIDFromHex(itemID)
+	if err != nil {
+		return nil, err
+	}
+	return r.page(ctx, "type = ? AND item_id = ?", []interface{}{itemType, itemObjectID.Hex()}, page)
+}
+
+// GetByUser lists a user's ratings, newest first.
+func (r *ratingRepository) GetByUser(ctx context.Context, userID string, page domain.PageRequest) (*domain.Page[*domain.Rating], error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.page(ctx, "user_id = ?", []interface{}{userObjectID.Hex()}, page)
+}
+
+func (r *ratingRepository) Create(ctx context.Context, rating *domain.Rating) error {
+	rating.ID = primitive.NewObjectID()
+	rating.CreatedAt = time.Now()
+	rating.UpdatedAt = time.Now()
+
+	_, err := r.db.ExecContext(ctx, "INSERT INTO ratings ("+ratingColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		rating.ID.Hex(), rating.UserID.Hex(), rating.Type, itemColumn(rating.Type, rating.MovieID, rating.TVShowID),
+		rating.Rating, rating.Review, formatTime(rating.CreatedAt), formatTime(rating.UpdatedAt))
+	return err
+}
+
+func (r *ratingRepository) Update(ctx context.Context, rating *domain.Rating) error {
+	rating.UpdatedAt = time.Now()
+
+	_, err := r.db.ExecContext(ctx, `UPDATE ratings SET user_id = ?, type = ?, item_id = ?, rating = ?, review = ?,
+		created_at = ?, updated_at = ? WHERE id = ?`,
+		rating.UserID.Hex(), rating.Type, itemColumn(rating.Type, rating.MovieID, rating.TVShowID),
+		rating.Rating, rating.Review, formatTime(rating.CreatedAt), formatTime(rating.UpdatedAt), rating.ID.Hex())
+	return err
+}
+
+func (r *ratingRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, "DELETE FROM ratings WHERE id = ?", objectID.Hex())
+	return err
+}
+
+// GetAverageRating returns the mean rating of an item and how many ratings
+// it is based on.
+func (r *ratingRepository) GetAverageRating(ctx context.Context, itemID string, itemType string) (float64, int64, error) {
+	itemObjectID, err := primitive.ObjectIDFromHex(itemID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var average float64
+	var count int64
+	err = r.db.QueryRowContext(ctx,
+		"SELECT COALESCE(AVG(rating), 0), COUNT(*) FROM ratings WHERE type = ? AND item_id = ?",
+		itemType, itemObjectID.Hex()).Scan(&average, &count)
+	return average, count, err
+}
+
+// DeleteByItems drops every rating of the given movies or TV shows.
+func (r *ratingRepository) DeleteByItems(ctx context.Context, itemIDs []string, itemType string) error {
+	args, err := parseIDs(itemIDs)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx,
+		"DELETE FROM ratings WHERE type = ? AND item_id IN ("+placeholders(len(args))+")",
+		append([]interface{}{itemType}, args...)...)
+	return err
+}
+
+// DeleteByUsers drops every rating by the given users.
+func (r *ratingRepository) DeleteByUsers(ctx context.Context, userIDs []string) error {
+	args, err := parseIDs(userIDs)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, "DELETE FROM ratings WHERE user_id IN ("+placeholders(len(args))+")", args...)
+	return err
+}
+
+// page lists the matching ratings, newest first.
+func (r *ratingRepository) page(ctx context.Context, where string, args []interface{}, page domain.PageRequest) (*domain.Page[*domain.Rating], error) {
+	return findPage(ctx, r.db, pageQuery[*domain.Rating]{
+		table:   "ratings",
+		columns: ratingColumns,
+		where:   where,
+		args:    args,
+		order:   newestFirst("created_at"),
+		scan: func(rows *sql.Rows, key ...interface{}) (*domain.Rating, error) {
+			return scanRating(rows, key...)
+		},
+	}, page)
+}
@@ -0,0 +1,134 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// softDelete marks a live row deleted, returning domain.ErrNotFound when
+// there is none.
+func softDelete(ctx context.Context, db *sql.DB, table, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	res, err := db.ExecContext(ctx,
+		"UPDATE "+table+" SET deleted_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL",
+		formatTime(time.Now()), objectID.Hex())
+	return affectedOne(res, err)
+}
+
+// restore undeletes a soft-deleted row, returning domain.ErrNotFound when
+// there is none.
+func restore(ctx context.Context, db *sql.DB, table, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	res, err := db.ExecContext(ctx,
+		"UPDATE "+table+" SET deleted_at = NULL, updated_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL",
+		formatTime(time.Now()), objectID.Hex())
+	return affectedOne(res, err)
+}
+
+// listDeleted returns the IDs of rows soft-deleted before the cutoff.
+func listDeleted(ctx context.Context, db *sql.DB, table string, deletedBefore time.Time) ([]string, error) {
+	rows, err := db.QueryContext(ctx,
+		"SELECT id FROM "+table+" WHERE deleted_at IS NOT NULL AND deleted_at < ?",
+		formatTime(deletedBefore))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// purge removes the given rows still soft-deleted before the cutoff for
+// good. Rows restored or deleted again in the meantime are left alone. It
+// returns the IDs no longer stored afterwards.
+func purge(ctx context.Context, db *sql.DB, table string, ids []string, deletedBefore time.Time) ([]string, error) {
+	args, err := parseIDs(ids)
+	if err != nil || len(args) == 0 {
+		return nil, err
+	}
+	if _, err = db.ExecContext(ctx,
+		"DELETE FROM "+table+" WHERE deleted_at IS NOT NULL AND deleted_at < ? AND id IN ("+placeholders(len(args))+")",
+		append([]interface{}{formatTime(deletedBefore)}, args...)...); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT id FROM "+table+" WHERE id IN ("+placeholders(len(args))+")", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stored := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		stored[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	purged := make([]string, 0, len(args))
+	for _, arg := range args {
+		if id := arg.(string); !stored[id] {
+			purged = append(purged, id)
+		}
+	}
+	return purged, nil
+}
+
+func affectedOne(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// versionedResult tells a lost version race from a missing row after a
+// versioned UPDATE.
+func versionedResult(ctx context.Context, db *sql.DB, table, id string, res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil || n > 0 {
+		return err
+	}
+
+	var exists bool
+	err = db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id = ? AND deleted_at IS NULL)", id).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrVersionConflict
+	}
+	return domain.ErrNotFound
+}
@@ -0,0 +1,126 @@
+// Package sqlite implements the domain repositories on a SQLite file, for
+// demos and small installs that don't run MongoDB. Semantics match the
+// MongoDB repositories, including soft deletes, versioned updates and
+// keyset cursors. Movies and TV shows are stored as JSON documents next to
+// the columns they are filtered and sorted on.
+//
+// The driver is mattn/go-sqlite3, which is C code: binaries that use this
+// package must be built with cgo enabled and a C compiler available.
+// Builds with CGO_ENABLED=0 still compile, but Open fails.
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Open opens (creating if needed) the database file at path. SQLite allows
+// one writer at a time, so the pool keeps a single connection and writers
+// queue up instead of failing with "database is locked".
+func Open(path string) (*sql.DB, error) {
+	if driverName == "" {
+		return nil, errNoCGO
+	}
+
+	dsn := fmt.Sprintf("file:%s?_foreign_keys=on&_journal_mode=WAL&_busy_timeout=5000", path)
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+var errNoCGO = errors.New("sqlite: this binary was built without cgo, which the SQLite driver needs")
+
+// New returns the SQLite-backed repositories for db. Run Migrate first.
+func New(db *sql.DB) domain.Repositories {
+	return domain.Repositories{
+		Movies:      NewMovieRepository(db),
+		TVShows:     NewTVShowRepository(db),
+		People:      NewPersonRepository(db),
+		Users:       NewUserRepository(db),
+		Watchlists:  NewWatchlistRepository(db),
+		Ratings:     NewRatingRepository(db),
+		Overrides:   NewOverrideRepository(db),
+		Checkpoints: NewCheckpointRepository(db),
+	}
+}
+
+// timeLayout has a fixed width so stored times order as strings.
+const timeLayout = "2006-01-02T15:04:05.000000000Z"
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+func parseTime(s string) (time.Time, error) {
+	return time.Parse(timeLayout, s)
+}
+
+// nullTime stores a nil time as NULL.
+func nullTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return formatTime(*t)
+}
+
+func parseNullTime(s sql.NullString) (*time.Time, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	t, err := parseTime(s.String)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// notFound translates a missing row into domain.ErrNotFound.
+func notFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+// parseIDs checks that every ID is a well-formed ObjectID, as the MongoDB
+// repositories do, and returns them as query arguments.
+func parseIDs(ids []string) ([]interface{}, error) {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = objectID.Hex()
+	}
+	return args, nil
+}
+
+// placeholders returns "?, ?, ..." for n arguments.
+func placeholders(n int) string {
+	if n == 0 {
+		return "NULL"
+	}
+	s := make([]byte, 0, 3*n)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			s = append(s, ", "...)
+		}
+		s = append(s, '?')
+	}
+	return string(s)
+}
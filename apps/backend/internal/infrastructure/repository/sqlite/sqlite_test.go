@@ -0,0 +1,29 @@
+//go:build cgo
+
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"backend/internal/domain"
+	"backend/internal/infrastructure/repository/repotest"
+	"backend/internal/infrastructure/repository/sqlite"
+)
+
+// TestRepositories runs the checks against a freshly migrated database file.
+func TestRepositories(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) domain.Repositories {
+		db, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		if _, err := sqlite.Migrate(context.Background(), db); err != nil {
+			t.Fatalf("migrating: %v", err)
+		}
+		return sqlite.New(db)
+	})
+}
@@ -0,0 +1,139 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"backend/internal/domain"
+	"backend/internal/infrastructure/repository/textsearch"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type tvShowRepository struct {
+	table *catalogTable[*domain.TVShow]
+}
+
+func NewTVShowRepository(db *sql.DB) domain.TVShowRepository {
+	return &tvShowRepository{
+		table: &catalogTable[*domain.TVShow]{
+			db:      db,
+			name:    "tv_shows",
+			newItem: func() *domain.TVShow { return &domain.TVShow{} },
+			record: func(m *domain.TVShow) catalogRecord {
+				return catalogRecord{
+					ID:          &m.ID,
+					TMDBID:      m.TMDBTVShowID,
+					Title:       m.Name,
+					ReleaseDate: m.FirstAirDate,
+					VoteAverage: m.VoteAverage,
+					VoteCount:   m.VoteCount,
+					Hidden:      &m.Hidden,
+					CreatedAt:   &m.CreatedAt,
+					UpdatedAt:   &m.UpdatedAt,
+					Version:     &m.Version,
+					DeletedAt:   &m.DeletedAt,
+				}
+			},
+			searchFields: func(m *domain.TVShow) []textsearch.Field {
+				return []textsearch.Field{
+					{Text: m.Name, Weight: 10},
+					{Text: m.Overview, Weight: 2},
+				}
+			},
+		},
+	}
+}
+
+func (r *tvShowRepository) GetByID(ctx context.Context, id string) (*domain.TVShow, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.table.getOne(ctx, "id = ?", objectID.Hex())
+}
+
+func (r *tvShowRepository) GetByTMDBID(ctx context.Context, tmdbID int) (*domain.TVShow, error) {
+	return r.table.getOne(ctx, "tmdb_id = ?", tmdbID)
+}
+
+func (r *tvShowRepository) GetByTMDBIDs(ctx context.Context, tmdbIDs []int) ([]*domain.TVShow, error) {
+	return r.table.getByTMDBIDs(ctx, tmdbIDs)
+}
+
+func (r *tvShowRepository) GetTMDBIDs(ctx context.Context, ids []string) (map[string]int, error) {
+	return r.table.tmdbIDs(ctx, ids)
+}
+
+func (r *tvShowRepository) Create(ctx context.Context, tvShow *domain.TVShow) error {
+	return r.table.create(ctx, tvShow)
+}
+
+// Upsert stores a TV show fetched in full from TMDB, keyed on its TMDB ID.
+func (r *tvShowRepository) Upsert(ctx context.Context, tvShow *domain.TVShow) error {
+	return r.table.upsertMany(ctx, []*domain.TVShow{tvShow}, (*domain.TVShow).ApplyUpstream)
+}
+
+// UpsertMany stores TV shows from a TMDB list result. Stored shows only get
+// the fields list results carry refreshed; new ones are inserted whole.
+func (r *tvShowRepository) UpsertMany(ctx context.Context, tvShows []*domain.TVShow) error {
+	return r.table.upsertMany(ctx, tvShows, (*domain.TVShow).ApplyListing)
+}
+
+func (r *tvShowRepository) Update(ctx context.Context, tvShow *domain.TVShow) error {
+	return r.table.update(ctx, tvShow)
+}
+
+// Delete soft-deletes a TV show; it stays stored until purged.
+func (r *tvShowRepository) Delete(ctx context.Context, id string) error {
+	return softDelete(ctx, r.table.db, r.table.name, id)
+}
+
+func (r *tvShowRepository) Restore(ctx context.Context, id string) error {
+	return restore(ctx, r.table.db, r.table.name, id)
+}
+
+func (r *tvShowRepository) ListDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	return listDeleted(ctx, r.table.db, r.table.name, deletedBefore)
+}
+
+func (r *tvShowRepository) Purge(ctx context.Context, ids []string, deletedBefore time.Time) ([]string, error) {
+	return purge(ctx, r.table.db, r.table.name, ids, deletedBefore)
+}
+
+func (r *tvShowRepository) List(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.TVShow], error) {
+	return r.Browse(ctx, domain.CatalogQuery{}, page)
+}
+
+// Search ranks TV shows by name and overview with the weights of
+// the Mongo text index, falling back to a name prefix match.
+func (r *tvShowRepository) Search(ctx context.Context, query string, limit, offset int, withTotal bool) ([]*domain.TVShowSearchResult, *int64, error) {
+	matches, total, err := r.table.search(ctx, query, limit, offset, withTotal)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := []*domain.TVShowSearchResult{}
+	for _, match := range matches {
+		results = append(results, &domain.TVShowSearchResult{TVShow: *match.Item, Score: match.Score})
+	}
+	return results, total, nil
+}
+
+func (r *tvShowRepository) GetByGenre(ctx context.Context, genreID int, page domain.PageRequest) (*domain.Page[*domain.TVShow], error) {
+	return r.Browse(ctx, domain.CatalogQuery{GenreID: genreID}, page)
+}
+
+func (r *tvShowRepository) GetPopular(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.TVShow], error) {
+	return r.Browse(ctx, domain.CatalogQuery{Sort: domain.SortByVote}, page)
+}
+
+// Browse lists one page of stored TV shows matching the query.
+func (r *tvShowRepository) Browse(ctx context.Context, query domain.CatalogQuery, page domain.PageRequest) (*domain.Page[*domain.TVShow], error) {
+	return r.table.browse(ctx, query, page)
+}
+
+func (r *tvShowRepository) ListStale(ctx context.Context, updatedBefore time.Time, after domain.RefreshPosition, limit int) ([]*domain.TVShow, error) {
+	return r.table.listStale(ctx, updatedBefore, after, limit)
+}
@@ -0,0 +1,133 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type userRepository struct {
+	db *sql.DB
+}
+
+func NewUserRepository(db *sql.DB) domain.UserRepository {
+	return &userRepository{db: db}
+}
+
+const userColumns = "id, email, username, first_name, last_name, avatar, is_active, created_at, updated_at, version, deleted_at"
+
+func scanUser(row scanner, extra ...interface{}) (*domain.User, error) {
+	var user domain.User
+	var id, createdAt, updatedAt string
+	var deletedAt sql.NullString
+	dest := append([]interface{}{&id, &user.Email, &user.Username, &user.FirstName, &user.LastName,
+		&user.Avatar, &user.IsActive, &createdAt, &updatedAt, &user.Version, &deletedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if user.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	if user.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if user.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	if user.DeletedAt, err = parseNullTime(deletedAt); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(ctx, "id = ?", objectID.Hex())
+}
+
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.findOne(ctx, "email = ?", email)
+}
+
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return r.findOne(ctx, "username = ?", username)
+}
+
+func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	user.ID = primitive.NewObjectID()
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+	user.Version = 1
+
+	_, err := r.db.ExecContext(ctx, "INSERT INTO users ("+userColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		user.ID.Hex(), user.Email, user.Username, user.FirstName, user.LastName, user.Avatar, user.IsActive,
+		formatTime(user.CreatedAt), formatTime(user.UpdatedAt), user.Version, nullTime(user.DeletedAt))
+	return err
+}
+
+func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	user.UpdatedAt = time.Now()
+	user.Version++
+
+	res, err := r.db.ExecContext(ctx, `UPDATE users SET email = ?, username = ?, first_name = ?, last_name = ?,
+		avatar = ?, is_active = ?, created_at = ?, updated_at = ?, version = ?
+		WHERE id = ? AND `+live+` AND version = ?`,
+		user.Email, user.Username, user.FirstName, user.LastName, user.Avatar, user.IsActive,
+		formatTime(user.CreatedAt), formatTime(user.UpdatedAt), user.Version,
+		user.ID.Hex(), user.Version-1)
+	if err == nil {
+		err = versionedResult(ctx, r.db, "users", user.ID.Hex(), res)
+	}
+	if err != nil {
+		user.Version--
+		return err
+	}
+	return nil
+}
+
+// Delete soft-deletes a user; it stays stored until purged.
+func (r *userRepository) Delete(ctx context.Context, id string) error {
+	return softDelete(ctx, r.db, "users", id)
+}
+
+func (r *userRepository) Restore(ctx context.Context, id string) error {
+	return restore(ctx, r.db, "users", id)
+}
+
+func (r *userRepository) ListDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	return listDeleted(ctx, r.db, "users", deletedBefore)
+}
+
+func (r *userRepository) Purge(ctx context.Context, ids []string, deletedBefore time.Time) ([]string, error) {
+	return purge(ctx, r.db, "users", ids, deletedBefore)
+}
+
+func (r *userRepository) List(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.User], error) {
+	return findPage(ctx, r.db, pageQuery[*domain.User]{
+		table:   "users",
+		columns: userColumns,
+		where:   live,
+		order:   byID,
+		scan: func(rows *sql.Rows, key ...interface{}) (*domain.User, error) {
+			return scanUser(rows, key...)
+		},
+	}, page)
+}
+
+func (r *userRepository) findOne(ctx context.Context, where string, args ...interface{}) (*domain.User, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE "+live+" AND "+where+" LIMIT 1", args...)
+	user, err := scanUser(row)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return user, nil
+}
@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type watchlistRepository struct {
+	db *sql.DB
+}
+
+func NewWatchlistRepository(db *sql.DB) domain.WatchlistRepository {
+	return &watchlistRepository{db: db}
+}
+
+const watchlistColumns = "id, user_id, type, item_id, added_at"
+
+func scanWatchlist(row scanner, extra ...interface{}) (*domain.Watchlist, error) {
+	var entry domain.Watchlist
+	var id, userID, addedAt string
+	var itemID sql.NullString
+	if err := row.Scan(append([]interface{}{&id, &userID, &entry.Type, &itemID, &addedAt}, extra...)...); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if entry.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	if entry.UserID, err = primitive.ObjectIDFromHex(userID); err != nil {
+		return nil, err
+	}
+	if entry.AddedAt, err = parseTime(addedAt); err != nil {
+		return nil, err
+	}
+	if err := setItem(entry.Type, itemID, &entry.MovieID, &entry.TVShowID); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetByUserID lists a user's watchlist, most recently added first.
+func (r *watchlistRepository) GetByUserID(ctx context.Context, userID string, page domain.PageRequest) (*domain.Page[*domain.Watchlist], error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return findPage(ctx, r.db, pageQuery[*domain.Watchlist]{
+		table:   "watchlists",
+		columns: watchlistColumns,
+		where:   "user_id = ?",
+		args:    []interface{}{userObjectID.Hex()},
+		order:   newestFirst("added_at"),
+		scan: func(rows *sql.Rows, key ...interface{}) (*domain.Watchlist, error) {
+			return scanWatchlist(rows, key...)
+		},
+	}, page)
+}
+
+func (r *watchlistRepository) Add(ctx context.Context, watchlist *domain.Watchlist) error {
+	if watchlist.ID.IsZero() {
+		watchlist.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.db.ExecContext(ctx, "INSERT INTO watchlists ("+watchlistColumns+") VALUES (?, ?, ?, ?, ?)",
+		watchlist.ID.Hex(), watchlist.UserID.Hex(), watchlist.Type,
+		itemColumn(watchlist.Type, watchlist.MovieID, watchlist.TVShowID), formatTime(watchlist.AddedAt))
+	return err
+}
+
+func (r *watchlistRepository) Remove(ctx context.Context, userID, itemID string, itemType string) error {
+	user, item, err := userItemArgs(userID, itemID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		"DELETE FROM watchlists WHERE id IN (SELECT id FROM watchlists WHERE user_id = ? AND type = ? AND item_id = ? LIMIT 1)",
+		user, itemType, item)
+	return err
+}
+
+func (r *watchlistRepository) IsInWatchlist(ctx context.Context, userID, itemID string, itemType string) (bool, error) {
+	user, item, err := userItemArgs(userID, itemID)
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	err = r.db.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM watchlists WHERE user_id = ? AND type = ? AND item_id = ?)",
+		user, itemType, item).Scan(&exists)
+	return exists, err
+}
+
+// RemoveByItems drops every watchlist entry for the given movies or TV
+// shows.
+func (r *watchlistRepository) RemoveByItems(ctx context.Context, itemIDs []string, itemType string) error {
+	args, err := parseIDs(itemIDs)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx,
+		"DELETE FROM watchlists WHERE type = ? AND item_id IN ("+placeholders(len(args))+")",
+		append([]interface{}{itemType}, args...)...)
+	return err
+}
+
+// RemoveByUsers drops every watchlist entry of the given users.
+func (r *watchlistRepository) RemoveByUsers(ctx context.Context, userIDs []string) error {
+	args, err := parseIDs(userIDs)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, "DELETE FROM watchlists WHERE user_id IN ("+placeholders(len(args))+")", args...)
+	return err
+}
@@ -0,0 +1,91 @@
+// Package textsearch ranks catalog search matches outside MongoDB the way
+// the weighted text indexes do, for the storage drivers that have no such
+// index.
+package textsearch
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Field is one searchable field and its weight, matching the weights of the
+// Mongo text index.
+type Field struct {
+	Text   string
+	Weight float64
+}
+
+// Terms splits raw user input into lowercase terms the way the Mongo text
+// filter reads it: quotes and leading minus signs carry no meaning.
+func Terms(query string) []string {
+	terms := strings.Fields(strings.ToLower(strings.ReplaceAll(query, `"`, " ")))
+	kept := terms[:0]
+	for _, term := range terms {
+		if term = strings.TrimLeft(term, "-"); term != "" {
+			kept = append(kept, term)
+		}
+	}
+	return kept
+}
+
+// Score approximates MongoDB's text score: every occurrence of a query term
+// in a field adds that field's weight. There is no stemming, so only whole
+// words match.
+func Score(terms []string, fields ...Field) float64 {
+	var score float64
+	for _, field := range fields {
+		words := strings.FieldsFunc(strings.ToLower(field.Text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, word := range words {
+			for _, term := range terms {
+				if word == term {
+					score += field.Weight
+				}
+			}
+		}
+	}
+	return score
+}
+
+// Fold normalizes a title for prefix matching: trimmed and lowercased.
+// Stored title keys and prefix queries both go through it.
+func Fold(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// HasPrefixFold reports whether title starts with the trimmed query,
+// ignoring case, like the Mongo prefix fallback.
+func HasPrefixFold(title, query string) bool {
+	query = strings.TrimSpace(query)
+	return len(title) >= len(query) && strings.EqualFold(title[:len(query)], query)
+}
+
+// Window applies offset and limit to a slice.
+func Window[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+	if limit >= 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// Match pairs an item with its text score.
+type Match[T any] struct {
+	Item  T
+	Score float64
+}
+
+// Rank sorts matches best first, breaking ties on the given key.
+func Rank[T any](matches []Match[T], tie func(T) string) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return tie(matches[i].Item) < tie(matches[j].Item)
+	})
+}
@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type tvShowRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTVShowRepository(db *mongo.Database) domain.TVShowRepository {
+	return &tvShowRepository{
+		collection: db.Collection("tv_shows"),
+	}
+}
+
+func (r *tvShowRepository) GetByID(ctx context.Context, id string) (*domain.TVShow, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var tvShow domain.TVShow
+	err = r.collection.FindOne(ctx, live(bson.M{"_id": objectID})).Decode(&tvShow)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	return &tvShow, nil
+}
+
+func (r *tvShowRepository) GetByTMDBID(ctx context.Context, tmdbID int) (*domain.TVShow, error) {
+	var tvShow domain.TVShow
+	err := r.collection.FindOne(ctx, live(bson.M{"tmdbTvShowId": tmdbID})).Decode(&tvShow)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	return &tvShow, nil
+}
+
+func (r *tvShowRepository) GetByTMDBIDs(ctx context.Context, tmdbIDs []int) ([]*domain.TVShow, error) {
+	cursor, err := r.collection.Find(ctx, live(bson.M{"tmdbTvShowId": bson.M{"$in": tmdbIDs}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tvShows []*domain.TVShow
+	if err = cursor.All(ctx, &tvShows); err != nil {
+		return nil, err
+	}
+
+	return tvShows, nil
+}
+
+func (r *tvShowRepository) GetTMDBIDs(ctx context.Context, ids []string) (map[string]int, error) {
+	return tmdbIDsByID(ctx, r.collection, "tmdbTvShowId", ids)
+}
+
+func (r *tvShowRepository) Create(ctx context.Context, tvShow *domain.TVShow) error {
+	tvShow.ID = primitive.NewObjectID()
+	tvShow.CreatedAt = time.Now()
+	tvShow.UpdatedAt = time.Now()
+	tvShow.Version = 1
+
+	_, err := r.collection.InsertOne(ctx, newTVShowDocument(tvShow))
+	return err
+}
+
+// Upsert stores a TV show fetched in full from TMDB, keyed on its TMDB ID.
+func (r *tvShowRepository) Upsert(ctx context.Context, tvShow *domain.TVShow) error {
+	return r.upsert(ctx, []*domain.TVShow{tvShow}, (*domain.TVShow).ApplyUpstream, tvShowUpstreamFields)
+}
+
+// UpsertMany stores TV shows from a TMDB list result. Stored shows only get
+// the fields list results carry refreshed; new ones are inserted whole.
+func (r *tvShowRepository) UpsertMany(ctx context.Context, tvShows []*domain.TVShow) error {
+	return r.upsert(ctx, tvShows, (*domain.TVShow).ApplyListing, tvShowListingFields)
+}
+
+// upsert inserts the TV shows not stored yet and refreshes the stored ones with
+// apply, writing the fields that set lists in a single bulk write. A stored
+// show that apply leaves unchanged isn't written, so its version stays put.
+// The stored ID, CreatedAt, UpdatedAt, Hidden flag, DeletedAt and Version are
+// copied back onto each show. Soft-deleted titles stay deleted.
+func (r *tvShowRepository) upsert(ctx context.Context, tvShows []*domain.TVShow, apply func(stored, upstream *domain.TVShow), set func(*domain.TVShow) bson.M) error {
+	if len(tvShows) == 0 {
+		return nil
+	}
+
+	tmdbIDs := make([]int, 0, len(tvShows))
+	for _, tvShow := range tvShows {
+		tmdbIDs = append(tmdbIDs, tvShow.TMDBTVShowID)
+	}
+	byTMDBID, err := r.storedByTMDBID(ctx, tmdbIDs, nil)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(tvShows))
+	for _, tvShow := range tvShows {
+		stored, ok := byTMDBID[tvShow.TMDBTVShowID]
+		if !ok {
+			writes = append(writes, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"tmdbTvShowId": tvShow.TMDBTVShowID}).
+				SetUpdate(bson.M{"$setOnInsert": insertFields(tvShowUpstreamFields(tvShow), now)}).
+				SetUpsert(true))
+			continue
+		}
+
+		updated := stored
+		apply(&updated, tvShow)
+		if reflect.DeepEqual(updated, stored) {
+			continue
+		}
+		fields := set(&updated)
+		fields["updatedAt"] = now
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": stored.ID, "version": versionFilter(stored.Version)}).
+			SetUpdate(bson.M{"$set": fields, "$inc": bumpVersion}))
+	}
+	if len(writes) == 0 {
+		copyTVShowBookkeeping(tvShows, byTMDBID)
+		return nil
+	}
+
+	if _, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return err
+	}
+
+	projection := bson.M{"_id": 1, "tmdbTvShowId": 1, "createdAt": 1, "updatedAt": 1, "hidden": 1, "deletedAt": 1, "version": 1}
+	if byTMDBID, err = r.storedByTMDBID(ctx, tmdbIDs, projection); err != nil {
+		return err
+	}
+	copyTVShowBookkeeping(tvShows, byTMDBID)
+	return nil
+}
+
+// storedByTMDBID loads the TV shows with the given TMDB IDs, soft-deleted ones
+// included, keyed on their TMDB ID. A nil projection loads whole documents.
+func (r *tvShowRepository) storedByTMDBID(ctx context.Context, tmdbIDs []int, projection bson.M) (map[int]domain.TVShow, error) {
+	opts := options.Find()
+	if projection != nil {
+		opts.SetProjection(projection)
+	}
+	cursor, err := r.collection.Find(ctx, bson.M{"tmdbTvShowId": bson.M{"$in": tmdbIDs}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stored []domain.TVShow
+	if err = cursor.All(ctx, &stored); err != nil {
+		return nil, err
+	}
+
+	byTMDBID := make(map[int]domain.TVShow, len(stored))
+	for _, s := range stored {
+		byTMDBID[s.TMDBTVShowID] = s
+	}
+	return byTMDBID, nil
+}
+
+func copyTVShowBookkeeping(tvShows []*domain.TVShow, byTMDBID map[int]domain.TVShow) {
+	for _, tvShow := range tvShows {
+		if s, ok := byTMDBID[tvShow.TMDBTVShowID]; ok {
+			tvShow.ID = s.ID
+			tvShow.CreatedAt = s.CreatedAt
+			tvShow.UpdatedAt = s.UpdatedAt
+			tvShow.Hidden = s.Hidden
+			tvShow.DeletedAt = s.DeletedAt
+			tvShow.Version = s.Version
+		}
+	}
+}
+
+func (r *tvShowRepository) Update(ctx context.Context, tvShow *domain.TVShow) error {
+	tvShow.UpdatedAt = time.Now()
+	tvShow.Version++
+
+	if err := updateVersioned(ctx, r.collection, tvShow.ID, tvShow.Version-1, newTVShowDocument(tvShow)); err != nil {
+		tvShow.Version--
+		return err
+	}
+	return nil
+}
+
+// Delete soft-deletes a TV show; it stays stored until purged.
+func (r *tvShowRepository) Delete(ctx context.Context, id string) error {
+	return softDelete(ctx, r.collection, id)
+}
+
+func (r *tvShowRepository) Restore(ctx context.Context, id string) error {
+	return restore(ctx, r.collection, id)
+}
+
+func (r *tvShowRepository) ListDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	return listDeleted(ctx, r.collection, deletedBefore)
+}
+
+func (r *tvShowRepository) Purge(ctx context.Context, ids []string, deletedBefore time.Time) ([]string, error) {
+	return purge(ctx, r.collection, ids, deletedBefore)
+}
+
+func (r *tvShowRepository) List(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.TVShow], error) {
+	return r.Browse(ctx, domain.CatalogQuery{}, page)
+}
+
+// Search ranks TV shows by relevance using the weighted text index. When the
+// text index finds nothing, it falls back to a name prefix match so partial
+// words still return results, unscored. Input made only of search operators
+// matches nothing, and the total is only counted when withTotal is set.
+func (r *tvShowRepository) Search(ctx context.Context, query string, limit, offset int, withTotal bool) ([]*domain.TVShowSearchResult, *int64, error) {
+	filter, ok := textSearchFilter(query)
+	if !ok {
+		return nil, noSearchTotal(withTotal), nil
+	}
+	results, total, matched, err := findSearchPage[*domain.TVShowSearchResult](ctx, r.collection, filter, textScoreFindOptions(limit, offset), offset, withTotal)
+	if err != nil || matched {
+		return results, total, err
+	}
+
+	results, total, _, err = findSearchPage[*domain.TVShowSearchResult](ctx, r.collection, prefixSearchFilter("nameKey", query), prefixFindOptions("nameKey", limit, offset), offset, withTotal)
+	return results, total, err
+}
+
+func (r *tvShowRepository) GetByGenre(ctx context.Context, genreID int, page domain.PageRequest) (*domain.Page[*domain.TVShow], error) {
+	return r.Browse(ctx, domain.CatalogQuery{GenreID: genreID}, page)
+}
+
+func (r *tvShowRepository) GetPopular(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.TVShow], error) {
+	return r.Browse(ctx, domain.CatalogQuery{Sort: domain.SortByVote}, page)
+}
+
+// Browse lists one page of stored tvShows matching the query.
+func (r *tvShowRepository) Browse(ctx context.Context, query domain.CatalogQuery, page domain.PageRequest) (*domain.Page[*domain.TVShow], error) {
+	return findPage[*domain.TVShow](ctx, r.collection, catalogFilter(query), catalogSort(query.Sort, "firstAirDate"), page)
+}
+
+func (r *tvShowRepository) ListStale(ctx context.Context, updatedBefore time.Time, after domain.RefreshPosition, limit int) ([]*domain.TVShow, error) {
+	cursor, err := r.collection.Find(ctx, staleFilter(updatedBefore, after), staleFindOptions(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tvShows []*domain.TVShow
+	if err = cursor.All(ctx, &tvShows); err != nil {
+		return nil, err
+	}
+
+	return tvShows, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"time"
+
+	"backend/internal/domain"
+	"backend/internal/infrastructure/repository/textsearch"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// movieDocument is a movie as stored, with the folded title the prefix
+// search fallback matches on.
+type movieDocument struct {
+	*domain.Movie `bson:",inline"`
+	TitleKey      string `bson:"titleKey"`
+}
+
+func newMovieDocument(movie *domain.Movie) movieDocument {
+	return movieDocument{Movie: movie, TitleKey: textsearch.Fold(movie.Title)}
+}
+
+// tvShowDocument is a TV show as stored, with the folded name the prefix
+// search fallback matches on.
+type tvShowDocument struct {
+	*domain.TVShow `bson:",inline"`
+	NameKey        string `bson:"nameKey"`
+}
+
+func newTVShowDocument(tvShow *domain.TVShow) tvShowDocument {
+	return tvShowDocument{TVShow: tvShow, NameKey: textsearch.Fold(tvShow.Name)}
+}
+
+// movieUpstreamFields lists the TMDB-sourced fields a full fetch overwrites.
+// Bookkeeping fields such as createdAt are left alone on existing documents.
+func movieUpstreamFields(movie *domain.Movie) bson.M {
+	return bson.M{
+		"tmdbMovieId":  movie.TMDBMovieID,
+		"title":        movie.Title,
+		"titleKey":     textsearch.Fold(movie.Title),
+		"overview":     movie.Overview,
+		"posterPath":   movie.PosterPath,
+		"backdropPath": movie.BackdropPath,
+		"releaseDate":  movie.ReleaseDate,
+		"runtime":      movie.Runtime,
+		"voteAverage":  movie.VoteAverage,
+		"voteCount":    movie.VoteCount,
+		"genres":       movie.Genres,
+		"adult":        movie.Adult,
+		"budget":       movie.Budget,
+		"revenue":      movie.Revenue,
+		"status":       movie.Status,
+		"tagline":      movie.Tagline,
+	}
+}
+
+// tvShowUpstreamFields lists the TMDB-sourced fields a full fetch overwrites.
+// Bookkeeping fields such as createdAt are left alone on existing documents.
+func tvShowUpstreamFields(tvShow *domain.TVShow) bson.M {
+	return bson.M{
+		"tmdbTvShowId":     tvShow.TMDBTVShowID,
+		"name":             tvShow.Name,
+		"nameKey":          textsearch.Fold(tvShow.Name),
+		"overview":         tvShow.Overview,
+		"posterPath":       tvShow.PosterPath,
+		"backdropPath":     tvShow.BackdropPath,
+		"firstAirDate":     tvShow.FirstAirDate,
+		"lastAirDate":      tvShow.LastAirDate,
+		"numberOfSeasons":  tvShow.NumberOfSeasons,
+		"numberOfEpisodes": tvShow.NumberOfEpisodes,
+		"voteAverage":      tvShow.VoteAverage,
+		"voteCount":        tvShow.VoteCount,
+		"genres":           tvShow.Genres,
+		"status":           tvShow.Status,
+		"type":             tvShow.Type,
+	}
+}
+
+// movieListingFields lists the fields TMDB list results carry, the only ones
+// a list upsert overwrites. It matches domain.Movie.ApplyListing.
+func movieListingFields(movie *domain.Movie) bson.M {
+	return bson.M{
+		"title":        movie.Title,
+		"titleKey":     textsearch.Fold(movie.Title),
+		"overview":     movie.Overview,
+		"posterPath":   movie.PosterPath,
+		"backdropPath": movie.BackdropPath,
+		"releaseDate":  movie.ReleaseDate,
+		"voteAverage":  movie.VoteAverage,
+		"voteCount":    movie.VoteCount,
+		"adult":        movie.Adult,
+	}
+}
+
+// tvShowListingFields lists the fields TMDB list results carry, the only ones
+// a list upsert overwrites. It matches domain.TVShow.ApplyListing.
+func tvShowListingFields(tvShow *domain.TVShow) bson.M {
+	return bson.M{
+		"name":         tvShow.Name,
+		"nameKey":      textsearch.Fold(tvShow.Name),
+		"overview":     tvShow.Overview,
+		"posterPath":   tvShow.PosterPath,
+		"backdropPath": tvShow.BackdropPath,
+		"firstAirDate": tvShow.FirstAirDate,
+		"voteAverage":  tvShow.VoteAverage,
+		"voteCount":    tvShow.VoteCount,
+	}
+}
+
+// insertFields extends the upstream fields of a new document with its
+// bookkeeping, for use under $setOnInsert.
+func insertFields(fields bson.M, now time.Time) bson.M {
+	fields["createdAt"] = now
+	fields["updatedAt"] = now
+	fields["version"] = int64(1)
+	return fields
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type userRepository struct {
+	collection *mongo.Collection
+}
+
+func NewUserRepository(db *mongo.Database) domain.UserRepository {
+	return &userRepository{
+		collection: db.Collection("users"),
+	}
+}
+
+func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.findOne(ctx, bson.M{"_id": objectID})
+}
+
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.findOne(ctx, bson.M{"email": email})
+}
+
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return r.findOne(ctx, bson.M{"username": username})
+}
+
+func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	user.ID = primitive.NewObjectID()
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+	user.Version = 1
+
+	_, err := r.collection.InsertOne(ctx, user)
+	return err
+}
+
+func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	user.UpdatedAt = time.Now()
+	user.Version++
+
+	if err := updateVersioned(ctx, r.collection, user.ID, user.Version-1, user); err != nil {
+		user.Version--
+		return err
+	}
+	return nil
+}
+
+// Delete soft-deletes a user; it stays stored until purged.
+func (r *userRepository) Delete(ctx context.Context, id string) error {
+	return softDelete(ctx, r.collection, id)
+}
+
+func (r *userRepository) Restore(ctx context.Context, id string) error {
+	return restore(ctx, r.collection, id)
+}
+
+func (r *userRepository) ListDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	return listDeleted(ctx, r.collection, deletedBefore)
+}
+
+func (r *userRepository) Purge(ctx context.Context, ids []string, deletedBefore time.Time) ([]string, error) {
+	return purge(ctx, r.collection, ids, deletedBefore)
+}
+
+func (r *userRepository) List(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.User], error) {
+	return findPage[*domain.User](ctx, r.collection, live(bson.M{}), bson.D{{Key: "_id", Value: 1}}, page)
+}
+
+func (r *userRepository) findOne(ctx context.Context, filter bson.M) (*domain.User, error) {
+	var user domain.User
+	if err := r.collection.FindOne(ctx, live(filter)).Decode(&user); err != nil {
+		return nil, notFound(err)
+	}
+
+	return &user, nil
+}
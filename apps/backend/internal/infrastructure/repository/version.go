@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// bumpVersion is merged into every write that changes a document, so any
+// edit based on an earlier read fails its version check.
+var bumpVersion = bson.M{"version": 1}
+
+// versionFilter matches a document still at the given version. Documents
+// written before versioning have no version field and count as version 0.
+func versionFilter(version int64) interface{} {
+	if version == 0 {
+		return bson.M{"$in": bson.A{0, nil}}
+	}
+	return version
+}
+
+// updateVersioned replaces a live document with doc if its stored version is
+// still expected. doc must already carry the next version. It returns
+// domain.ErrVersionConflict when the document has moved on and
+// domain.ErrNotFound when it doesn't exist.
+func updateVersioned(ctx context.Context, collection *mongo.Collection, id primitive.ObjectID, expected int64, doc interface{}) error {
+	result, err := collection.UpdateOne(ctx,
+		live(bson.M{"_id": id, "version": versionFilter(expected)}),
+		bson.M{"$set": doc},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	count, err := collection.CountDocuments(ctx, live(bson.M{"_id": id}))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return domain.ErrVersionConflict
+	}
+	return domain.ErrNotFound
+}
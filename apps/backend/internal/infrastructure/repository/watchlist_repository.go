@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+
+	"backend/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type watchlistRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWatchlistRepository(db *mongo.Database) domain.WatchlistRepository {
+	return &watchlistRepository{
+		collection: db.Collection("watchlists"),
+	}
+}
+
+// GetByUserID lists a user's watchlist, most recently added first.
+func (r *watchlistRepository) GetByUserID(ctx context.Context, userID string, page domain.PageRequest) (*domain.Page[*domain.Watchlist], error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return findPage[*domain.Watchlist](ctx, r.collection, bson.M{"userId": userObjectID}, recentlyAdded, page)
+}
+
+var recentlyAdded = bson.D{{Key: "addedAt", Value: -1}, {Key: "_id", Value: 1}}
+
+func (r *watchlistRepository) Add(ctx context.Context, watchlist *domain.Watchlist) error {
+	if watchlist.ID.IsZero() {
+		watchlist.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.collection.InsertOne(ctx, watchlist)
+	return err
+}
+
+func (r *watchlistRepository) Remove(ctx context.Context, userID, itemID string, itemType string) error {
+	filter, err := userItemFilter(userID, itemID, itemType)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteOne(ctx, filter)
+	return err
+}
+
+func (r *watchlistRepository) IsInWatchlist(ctx context.Context, userID, itemID string, itemType string) (bool, error) {
+	filter, err := userItemFilter(userID, itemID, itemType)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// RemoveByItems drops every watchlist entry for the given movies or TV
+// shows.
+func (r *watchlistRepository) RemoveByItems(ctx context.Context, itemIDs []string, itemType string) error {
+	filter, err := itemsFilter(itemIDs, itemType)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteMany(ctx, filter)
+	return err
+}
+
+// RemoveByUsers drops every watchlist entry of the given users.
+func (r *watchlistRepository) RemoveByUsers(ctx context.Context, userIDs []string) error {
+	userObjectIDs, err := objectIDs(userIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteMany(ctx, bson.M{"userId": bson.M{"$in": userObjectIDs}})
+	return err
+}
+
+// itemFilter matches watchlist or rating entries for one movie or TV show.
+func itemFilter(itemID, itemType string) (bson.M, error) {
+	itemObjectID, err := primitive.ObjectIDFromHex(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if itemType == "tv" {
+		return bson.M{"type": itemType, "tvShowId": itemObjectID}, nil
+	}
+	return bson.M{"type": itemType, "movieId": itemObjectID}, nil
+}
+
+// itemsFilter matches watchlist or rating entries for any of the given
+// movies or TV shows.
+func itemsFilter(itemIDs []string, itemType string) (bson.M, error) {
+	itemObjectIDs, err := objectIDs(itemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if itemType == "tv" {
+		return bson.M{"type": itemType, "tvShowId": bson.M{"$in": itemObjectIDs}}, nil
+	}
+	return bson.M{"type": itemType, "movieId": bson.M{"$in": itemObjectIDs}}, nil
+}
+
+// userItemFilter matches one user's watchlist or rating entry for an item.
+func userItemFilter(userID, itemID, itemType string) (bson.M, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := itemFilter(itemID, itemType)
+	if err != nil {
+		return nil, err
+	}
+	filter["userId"] = userObjectID
+
+	return filter, nil
+}
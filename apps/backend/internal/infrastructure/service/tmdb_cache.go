@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxCacheEntries bounds the response cache; expired entries are swept once
+// it fills up and everything is dropped if that isn't enough.
+const maxCacheEntries = 10000
+
+type cacheEntry struct {
+	body      []byte
+	fetchedAt time.Time
+}
+
+// responseCache keeps raw TMDB answers keyed by URL. Freshness is checked
+// against the TTL in effect at lookup, so a reloaded TTL applies at once.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: make(map[string]cacheEntry)}
+}
+
+func (c *responseCache) get(url string, ttl time.Duration) ([]byte, bool) {
+	if ttl <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[url]
+	if !ok || time.Since(entry.fetchedAt) >= ttl {
+		return nil, false
+	}
+	return entry.body, true
+}
+
+func (c *responseCache) put(url string, body []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxCacheEntries {
+		for key, entry := range c.entries {
+			if time.Since(entry.fetchedAt) >= ttl {
+				delete(c.entries, key)
+			}
+		}
+		if len(c.entries) >= maxCacheEntries {
+			c.entries = make(map[string]cacheEntry)
+		}
+	}
+	c.entries[url] = cacheEntry{body: body, fetchedAt: time.Now()}
+}
+
+// requestLimiter spaces TMDB requests to stay within a requests-per-second
+// budget that may change between calls.
+type requestLimiter struct {
+	mu   sync.Mutex
+	next time.Time
+}
+
+// wait blocks until the next request is allowed at perSecond, or ctx is
+// done. A zero rate doesn't limit.
+func (l *requestLimiter) wait(ctx context.Context, perSecond float64) error {
+	if perSecond <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(time.Duration(float64(time.Second) / perSecond))
+	l.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
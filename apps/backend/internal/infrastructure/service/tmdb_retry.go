@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/internal/metrics"
+	"backend/internal/requestid"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("backend/internal/infrastructure/service")
+
+// maxRetryWait caps how long a single retry waits, whatever TMDB asks for
+// in Retry-After.
+const maxRetryWait = 10 * time.Second
+
+// APIError is a non-200 answer from TMDB.
+type APIError struct {
+	StatusCode int
+	// RetryAfter is the wait TMDB asked for, zero when it didn't say.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("TMDB API error: %d", e.StatusCode)
+}
+
+// retryable reports whether a failed request may succeed when sent again:
+// rate limiting, server errors and network failures are, anything else the
+// request itself got wrong isn't.
+func retryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// get fetches url and decodes the JSON answer into out, retrying up to
+// maxRetries times with exponential backoff. A Retry-After header on a 429
+// takes precedence over the backoff. Answers are served from the cache while
+// they are younger than the configured TTL.
+func (s *TMDBService) get(ctx context.Context, url string, out interface{}) error {
+	rt := s.config.Runtime()
+	if rt.TMDBCacheTTL > 0 {
+		body, ok := s.cache.get(url, rt.TMDBCacheTTL)
+		if ok {
+			metrics.CacheRequests.WithLabelValues("tmdb", "hit").Inc()
+			slog.Debug("TMDB cache hit", "path", redactedPath(url))
+			return json.Unmarshal(body, out)
+		}
+		metrics.CacheRequests.WithLabelValues("tmdb", "miss").Inc()
+	}
+
+	body, err := s.fetch(ctx, url, rt.TMDBRateLimit)
+	if err != nil {
+		return err
+	}
+	s.cache.put(url, body, rt.TMDBCacheTTL)
+	return json.Unmarshal(body, out)
+}
+
+func (s *TMDBService) fetch(ctx context.Context, url string, rateLimit float64) ([]byte, error) {
+	wait := s.retryBackoff
+	for attempt := 0; ; attempt++ {
+		if err := s.limiter.wait(ctx, rateLimit); err != nil {
+			return nil, err
+		}
+		body, err := s.getOnce(ctx, url)
+		if err == nil || attempt >= s.maxRetries || !retryable(err) {
+			return body, err
+		}
+
+		delay := wait
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			delay = apiErr.RetryAfter
+		}
+		if delay > maxRetryWait {
+			delay = maxRetryWait
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+		wait *= 2
+	}
+}
+
+func (s *TMDBService) getOnce(ctx context.Context, url string) ([]byte, error) {
+	endpoint := s.endpoint(url)
+	ctx, span := tracer.Start(ctx, "TMDB GET "+endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", "GET"),
+			attribute.String("tmdb.endpoint", endpoint),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+	body, err := s.do(ctx, url)
+	metrics.TMDBRequestDuration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+
+	status, reason := "200", ""
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &apiErr):
+		status = strconv.Itoa(apiErr.StatusCode)
+		switch {
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			reason = "rate_limited"
+		case apiErr.StatusCode >= 500:
+			reason = "server_error"
+		default:
+			reason = "client_error"
+		}
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		status, reason = "error", "canceled"
+	case err != nil:
+		status, reason = "error", "network"
+	}
+	metrics.TMDBRequests.WithLabelValues(endpoint, status).Inc()
+	if reason != "" {
+		metrics.TMDBRequestErrors.WithLabelValues(endpoint, reason).Inc()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, reason)
+	}
+	if apiErr != nil || err == nil {
+		code := http.StatusOK
+		if apiErr != nil {
+			code = apiErr.StatusCode
+		}
+		span.SetAttributes(attribute.Int("http.response.status_code", code))
+	}
+	return body, err
+}
+
+func (s *TMDBService) do(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if id := requestid.FromContext(ctx); id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			apiErr.RetryAfter = time.Duration(seconds) * time.Second
+		}
+		return nil, apiErr
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// endpoint names the TMDB endpoint url calls for metrics, with numeric
+// path segments replaced by :id to keep the label set small.
+func (s *TMDBService) endpoint(url string) string {
+	segments := strings.Split(strings.TrimPrefix(redactedPath(url), s.baseURL), "/")
+	for i, segment := range segments {
+		if _, err := strconv.Atoi(segment); err == nil {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// redactedPath strips the query, and with it the API key, from a TMDB URL
+// for logging.
+func redactedPath(url string) string {
+	path, _, _ := strings.Cut(url, "?")
+	return path
+}
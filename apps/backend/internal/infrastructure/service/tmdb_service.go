@@ -12,10 +12,14 @@ import (
 )
 
 type TMDBService struct {
-	client  *http.Client
-	config  *config.Config
-	baseURL string
-	apiKey  string
+	client       *http.Client
+	config       *config.Config
+	baseURL      string
+	apiKey       string
+	maxRetries   int
+	retryBackoff time.Duration
+	cache        *responseCache
+	limiter      requestLimiter
 }
 
 func NewTMDBService(cfg *config.Config) *TMDBService {
@@ -23,9 +27,12 @@ func NewTMDBService(cfg *config.Config) *TMDBService {
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		config:  cfg,
-		baseURL: cfg.TMDBBaseURL,
-		apiKey:  cfg.TMDBAPIKey,
+		config:       cfg,
+		baseURL:      cfg.TMDBBaseURL,
+		apiKey:       cfg.TMDBAPIKey,
+		maxRetries:   cfg.TMDBMaxRetries,
+		retryBackoff: cfg.TMDBRetryBackoff,
+		cache:        newResponseCache(),
 	}
 }
 
@@ -65,6 +72,18 @@ type TMDBTVShowResponse struct {
 	Genres           []TMDBGenre `json:"genres"`
 }
 
+type TMDBPersonResponse struct {
+	ID                 int     `json:"id"`
+	Name               string  `json:"name"`
+	Biography          string  `json:"biography"`
+	ProfilePath        string  `json:"profile_path"`
+	KnownForDepartment string  `json:"known_for_department"`
+	Birthday           string  `json:"birthday"`
+	Deathday           string  `json:"deathday"`
+	PlaceOfBirth       string  `json:"place_of_birth"`
+	Popularity         float64 `json:"popularity"`
+}
+
 type TMDBGenre struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
@@ -81,26 +100,20 @@ type TMDBGenresResponse struct {
 	Genres []TMDBGenre `json:"genres"`
 }
 
+type TMDBChangesResponse struct {
+	Results []struct {
+		ID int `json:"id"`
+	} `json:"results"`
+	Page         int `json:"page"`
+	TotalPages   int `json:"total_pages"`
+	TotalResults int `json:"total_results"`
+}
+
 func (s *TMDBService) GetMovie(ctx context.Context, movieID int) (*domain.Movie, error) {
 	url := fmt.Sprintf("%s/movie/%d?api_key=%s", s.baseURL, movieID, s.apiKey)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("TMDB API error: %d", resp.StatusCode)
-	}
-
 	var tmdbMovie TMDBMovieResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tmdbMovie); err != nil {
+	if err := s.get(ctx, url, &tmdbMovie); err != nil {
 		return nil, err
 	}
 
@@ -110,49 +123,40 @@ func (s *TMDBService) GetMovie(ctx context.Context, movieID int) (*domain.Movie,
 func (s *TMDBService) GetTVShow(ctx context.Context, tvShowID int) (*domain.TVShow, error) {
 	url := fmt.Sprintf("%s/tv/%d?api_key=%s", s.baseURL, tvShowID, s.apiKey)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
+	var tmdbTVShow TMDBTVShowResponse
+	if err := s.get(ctx, url, &tmdbTVShow); err != nil {
 		return nil, err
 	}
 
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return s.convertTMDBTVShowToTVShow(tmdbTVShow), nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("TMDB API error: %d", resp.StatusCode)
-	}
+func (s *TMDBService) GetPerson(ctx context.Context, personID int) (*domain.Person, error) {
+	url := fmt.Sprintf("%s/person/%d?api_key=%s", s.baseURL, personID, s.apiKey)
 
-	var tmdbTVShow TMDBTVShowResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tmdbTVShow); err != nil {
+	var tmdbPerson TMDBPersonResponse
+	if err := s.get(ctx, url, &tmdbPerson); err != nil {
 		return nil, err
 	}
 
-	return s.convertTMDBTVShowToTVShow(tmdbTVShow), nil
+	return &domain.Person{
+		TMDBPersonID:       tmdbPerson.ID,
+		Name:               tmdbPerson.Name,
+		Biography:          tmdbPerson.Biography,
+		ProfilePath:        tmdbPerson.ProfilePath,
+		KnownForDepartment: tmdbPerson.KnownForDepartment,
+		Birthday:           tmdbPerson.Birthday,
+		Deathday:           tmdbPerson.Deathday,
+		PlaceOfBirth:       tmdbPerson.PlaceOfBirth,
+		Popularity:         tmdbPerson.Popularity,
+	}, nil
 }
 
 func (s *TMDBService) SearchMovies(ctx context.Context, query string, page int) ([]*domain.Movie, int, error) {
 	url := fmt.Sprintf("%s/search/movie?api_key=%s&query=%s&page=%d", s.baseURL, s.apiKey, query, page)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, 0, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, 0, fmt.Errorf("TMDB API error: %d", resp.StatusCode)
-	}
-
 	var searchResp TMDBSearchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&searchResp); err != nil {
+	if err := s.get(ctx, url, &searchResp); err != nil {
 		return nil, 0, err
 	}
 
@@ -171,23 +175,8 @@ func (s *TMDBService) SearchMovies(ctx context.Context, query string, page int)
 func (s *TMDBService) SearchTVShows(ctx context.Context, query string, page int) ([]*domain.TVShow, int, error) {
 	url := fmt.Sprintf("%s/search/tv?api_key=%s&query=%s&page=%d", s.baseURL, s.apiKey, query, page)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, 0, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, 0, fmt.Errorf("TMDB API error: %d", resp.StatusCode)
-	}
-
 	var searchResp TMDBSearchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&searchResp); err != nil {
+	if err := s.get(ctx, url, &searchResp); err != nil {
 		return nil, 0, err
 	}
 
@@ -223,26 +212,18 @@ func (s *TMDBService) GetTVShowsByGenre(ctx context.Context, genreID int, page i
 	return s.getTVShowsList(ctx, url)
 }
 
+// Ping checks that TMDB answers and accepts the API key. It bypasses the
+// cache and retries so the answer reflects TMDB right now.
+func (s *TMDBService) Ping(ctx context.Context) error {
+	_, err := s.getOnce(ctx, fmt.Sprintf("%s/configuration?api_key=%s", s.baseURL, s.apiKey))
+	return err
+}
+
 func (s *TMDBService) GetGenres(ctx context.Context, mediaType string) ([]domain.Genre, error) {
 	url := fmt.Sprintf("%s/genre/%s/list?api_key=%s", s.baseURL, mediaType, s.apiKey)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("TMDB API error: %d", resp.StatusCode)
-	}
-
 	var genresResp TMDBGenresResponse
-	if err := json.NewDecoder(resp.Body).Decode(&genresResp); err != nil {
+	if err := s.get(ctx, url, &genresResp); err != nil {
 		return nil, err
 	}
 
@@ -257,25 +238,30 @@ func (s *TMDBService) GetGenres(ctx context.Context, mediaType string) ([]domain
 	return genres, nil
 }
 
-// Helper methods
-func (s *TMDBService) getMoviesList(ctx context.Context, url string) ([]*domain.Movie, int, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, 0, err
-	}
+// GetChanges returns the IDs of movies, TV shows or people (mediaType
+// "movie", "tv" or "person") changed on TMDB between the two dates, along
+// with the total number of pages. TMDB accepts windows of at most 14 days.
+func (s *TMDBService) GetChanges(ctx context.Context, mediaType string, startDate, endDate time.Time, page int) ([]int, int, error) {
+	url := fmt.Sprintf("%s/%s/changes?api_key=%s&start_date=%s&end_date=%s&page=%d",
+		s.baseURL, mediaType, s.apiKey, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"), page)
 
-	resp, err := s.client.Do(req)
-	if err != nil {
+	var changesResp TMDBChangesResponse
+	if err := s.get(ctx, url, &changesResp); err != nil {
 		return nil, 0, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, 0, fmt.Errorf("TMDB API error: %d", resp.StatusCode)
+	ids := make([]int, 0, len(changesResp.Results))
+	for _, result := range changesResp.Results {
+		ids = append(ids, result.ID)
 	}
 
+	return ids, changesResp.TotalPages, nil
+}
+
+// Helper methods
+func (s *TMDBService) getMoviesList(ctx context.Context, url string) ([]*domain.Movie, int, error) {
 	var searchResp TMDBSearchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&searchResp); err != nil {
+	if err := s.get(ctx, url, &searchResp); err != nil {
 		return nil, 0, err
 	}
 
@@ -292,23 +278,8 @@ func (s *TMDBService) getMoviesList(ctx context.Context, url string) ([]*domain.
 }
 
 func (s *TMDBService) getTVShowsList(ctx context.Context, url string) ([]*domain.TVShow, int, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, 0, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, 0, fmt.Errorf("TMDB API error: %d", resp.StatusCode)
-	}
-
 	var searchResp TMDBSearchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&searchResp); err != nil {
+	if err := s.get(ctx, url, &searchResp); err != nil {
 		return nil, 0, err
 	}
 
@@ -0,0 +1,337 @@
+// Package tmdbfake is a stand-in for the TMDB API. It answers from JSON
+// fixtures on disk (or a small built-in set), can record real TMDB answers
+// into fixtures, and can be told to slow down or fail so the TMDB client's
+// retry and fallback paths can be exercised. Point TMDB_BASE_URL at it, or
+// wrap it in an httptest.Server in code.
+//
+// A request is answered from "<path>@<query>.json", falling back to
+// "<path>.json", with the api_key parameter ignored. /movie/popular?page=2
+// looks for "movie/popular@page=2.json", then "movie/popular.json".
+package tmdbfake
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed fixtures
+var builtin embed.FS
+
+// ScenarioPath reads (GET) and changes (PUT) the scenario of a running
+// fake. PUT takes any of latency, rateLimitRate, serverErrorRate,
+// retryAfter, failNext and failStatus as query parameters, e.g.
+// PUT /__fake/scenario?latency=200ms&rateLimitRate=0.5.
+const ScenarioPath = "/__fake/scenario"
+
+type Config struct {
+	// Dir holds the fixtures. Empty serves the built-in set.
+	Dir string
+	// Record fetches every request from Upstream and saves successful
+	// answers into Dir, replacing existing fixtures.
+	Record bool
+	// Upstream is the real API base URL, e.g. https://api.themoviedb.org/3.
+	Upstream string
+	// APIKey replaces the caller's api_key on recorded requests when set.
+	APIKey string
+	// Scenario is the initial scenario.
+	Scenario Scenario
+	// Seed makes the random failures repeatable.
+	Seed int64
+}
+
+// Scenario describes how badly the fake behaves.
+type Scenario struct {
+	// Latency delays every answer.
+	Latency time.Duration
+	// RateLimitRate is the share of requests, 0 to 1, answered with 429.
+	RateLimitRate float64
+	// ServerErrorRate is the share of requests answered with 500.
+	ServerErrorRate float64
+	// RetryAfter is sent with every 429, rounded to whole seconds.
+	RetryAfter time.Duration
+	// FailNext makes the next requests fail with FailStatus (503 if unset)
+	// before the rates apply.
+	FailNext   int
+	FailStatus int
+}
+
+// Server is the fake TMDB API. It is safe for concurrent use.
+type Server struct {
+	cfg      Config
+	fixtures fs.FS
+	client   *http.Client
+
+	mu       sync.Mutex
+	scenario Scenario
+	rand     *rand.Rand
+	requests int
+}
+
+func New(cfg Config) (*Server, error) {
+	var fixtures fs.FS
+	switch {
+	case cfg.Dir != "":
+		fixtures = os.DirFS(cfg.Dir)
+	case cfg.Record:
+		return nil, errors.New("tmdbfake: record mode needs a fixtures directory")
+	default:
+		fixtures, _ = fs.Sub(builtin, "fixtures")
+	}
+	if cfg.Record && cfg.Upstream == "" {
+		return nil, errors.New("tmdbfake: record mode needs an upstream URL")
+	}
+
+	return &Server{
+		cfg:      cfg,
+		fixtures: fixtures,
+		client:   &http.Client{Timeout: 30 * time.Second},
+		scenario: cfg.Scenario,
+		rand:     rand.New(rand.NewSource(cfg.Seed)),
+	}, nil
+}
+
+// Scenario returns the current scenario.
+func (s *Server) Scenario() Scenario {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.scenario
+}
+
+// SetScenario replaces the scenario for every following request.
+func (s *Server) SetScenario(scenario Scenario) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scenario = scenario
+}
+
+// Requests returns how many API requests the fake has received, failed ones
+// included.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == ScenarioPath {
+		s.serveScenario(w, r)
+		return
+	}
+
+	latency, failStatus, retryAfter := s.roll()
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+
+	switch {
+	case failStatus == http.StatusTooManyRequests:
+		if seconds := int(retryAfter.Round(time.Second) / time.Second); seconds > 0 {
+			w.Header().Set("Retry-After", fmt.Sprint(seconds))
+		}
+		writeError(w, failStatus, 25, "Your request count (#) is over the allowed limit of (40).")
+	case failStatus != 0:
+		writeError(w, failStatus, 11, "Internal error: Something went wrong, contact TMDB.")
+	case s.cfg.Record:
+		s.record(w, r)
+	default:
+		s.serveFixture(w, r)
+	}
+}
+
+// roll counts the request and decides, under the lock, whether it fails.
+func (s *Server) roll() (latency time.Duration, failStatus int, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	sc := &s.scenario
+	if sc.FailNext > 0 {
+		sc.FailNext--
+		failStatus = sc.FailStatus
+		if failStatus == 0 {
+			failStatus = http.StatusServiceUnavailable
+		}
+		return sc.Latency, failStatus, sc.RetryAfter
+	}
+
+	switch roll := s.rand.Float64(); {
+	case roll < sc.RateLimitRate:
+		failStatus = http.StatusTooManyRequests
+	case roll < sc.RateLimitRate+sc.ServerErrorRate:
+		failStatus = http.StatusInternalServerError
+	}
+	return sc.Latency, failStatus, sc.RetryAfter
+}
+
+func (s *Server) serveFixture(w http.ResponseWriter, r *http.Request) {
+	for _, name := range fixtureNames(r.URL) {
+		body, err := fs.ReadFile(s.fixtures, name)
+		if err != nil {
+			continue
+		}
+		w.Header().Set("Content-Type", "application/json;charset=utf-8")
+		w.Write(body)
+		return
+	}
+	writeError(w, http.StatusNotFound, 34, "The resource you requested could not be found.")
+}
+
+// record relays the request to the real API and saves a successful answer
+// as the request's most specific fixture.
+func (s *Server) record(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if s.cfg.APIKey != "" {
+		query.Set("api_key", s.cfg.APIKey)
+	}
+	upstream := strings.TrimRight(s.cfg.Upstream, "/") + "/" + strings.Trim(r.URL.Path, "/") + "?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, upstream, nil)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, 11, err.Error())
+		return
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, 11, err.Error())
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, 11, err.Error())
+		return
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		name := fixtureNames(r.URL)[0]
+		if err := s.save(name, body); err != nil {
+			writeError(w, http.StatusInternalServerError, 11, err.Error())
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		w.Header().Set("Retry-After", retryAfter)
+	}
+	w.WriteHeader(resp.StatusCode)
+	w.Write(body)
+}
+
+func (s *Server) save(name string, body []byte) error {
+	if !fs.ValidPath(name) {
+		return fmt.Errorf("tmdbfake: refusing to record %q", name)
+	}
+	path := filepath.Join(s.cfg.Dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, body, 0o644)
+}
+
+// scenarioJSON is Scenario with durations spelled out, e.g. "250ms".
+type scenarioJSON struct {
+	Latency         string  `json:"latency"`
+	RateLimitRate   float64 `json:"rateLimitRate"`
+	ServerErrorRate float64 `json:"serverErrorRate"`
+	RetryAfter      string  `json:"retryAfter"`
+	FailNext        int     `json:"failNext"`
+	FailStatus      int     `json:"failStatus"`
+}
+
+func (s *Server) serveScenario(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		scenario, err := parseScenario(s.Scenario(), r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.SetScenario(scenario)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	sc := s.Scenario()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(scenarioJSON{
+		Latency:         sc.Latency.String(),
+		RateLimitRate:   sc.RateLimitRate,
+		ServerErrorRate: sc.ServerErrorRate,
+		RetryAfter:      sc.RetryAfter.String(),
+		FailNext:        sc.FailNext,
+		FailStatus:      sc.FailStatus,
+	})
+}
+
+// parseScenario applies the given query parameters on top of scenario.
+func parseScenario(scenario Scenario, params url.Values) (Scenario, error) {
+	var err error
+	for name := range params {
+		value := params.Get(name)
+		switch name {
+		case "latency":
+			scenario.Latency, err = time.ParseDuration(value)
+		case "rateLimitRate":
+			_, err = fmt.Sscan(value, &scenario.RateLimitRate)
+		case "serverErrorRate":
+			_, err = fmt.Sscan(value, &scenario.ServerErrorRate)
+		case "retryAfter":
+			scenario.RetryAfter, err = time.ParseDuration(value)
+		case "failNext":
+			_, err = fmt.Sscan(value, &scenario.FailNext)
+		case "failStatus":
+			_, err = fmt.Sscan(value, &scenario.FailStatus)
+		default:
+			err = errors.New("unknown setting")
+		}
+		if err != nil {
+			return scenario, fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	return scenario, nil
+}
+
+// fixtureNames lists the fixtures that can answer u, most specific first.
+func fixtureNames(u *url.URL) []string {
+	path := strings.Trim(u.Path, "/")
+	query := u.Query()
+	query.Del("api_key")
+	if len(query) == 0 {
+		return []string{path + ".json"}
+	}
+	return []string{path + "@" + query.Encode() + ".json", path + ".json"}
+}
+
+// writeError answers the way TMDB does when a request fails.
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status_code":    code,
+		"status_message": message,
+		"success":        false,
+	})
+}
@@ -0,0 +1,85 @@
+// Package metrics defines the Prometheus collectors the API exports on
+// /metrics. They are registered with the default registry, next to the Go
+// runtime and process collectors.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "movies"
+
+// HTTP server
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests served, by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Time taken to serve HTTP requests, by method, route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "HTTP requests currently being served.",
+	})
+
+	RateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "rate_limited_total",
+		Help:      "HTTP requests rejected with 429, by rate limit group.",
+	}, []string{"group"})
+)
+
+// TMDB client. Every attempt counts, retries included.
+var (
+	TMDBRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "tmdb",
+		Name:      "requests_total",
+		Help:      "Requests sent to TMDB, by endpoint and status code, or \"error\" when no answer came back.",
+	}, []string{"endpoint", "status"})
+
+	TMDBRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "tmdb",
+		Name:      "request_duration_seconds",
+		Help:      "Time taken by TMDB requests, by endpoint.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"endpoint"})
+
+	TMDBRequestErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "tmdb",
+		Name:      "request_errors_total",
+		Help:      "Failed TMDB requests, by endpoint and reason: rate_limited, server_error, client_error, network or canceled.",
+	}, []string{"endpoint", "reason"})
+)
+
+// MongoDB
+var MongoOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "mongo",
+	Name:      "operation_duration_seconds",
+	Help:      "Time taken by MongoDB commands, by command name and outcome.",
+	Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+}, []string{"command", "outcome"})
+
+// CacheRequests counts cache lookups; the hit ratio is hits over the total.
+var CacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "cache",
+	Name:      "requests_total",
+	Help:      "Cache lookups, by cache and result: hit or miss.",
+}, []string{"cache", "result"})
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"backend/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORS applies the reloadable CORS policy, read on every request. Allowed
+// origins are echoed back, so "*" is only ever sent when any origin is
+// allowed and credentials aren't. Preflight requests are answered here;
+// preflights from other origins get 403.
+func CORS(cfg *config.Config) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		policy := cfg.Runtime().CORS
+		c.Writer.Header().Add("Vary", "Origin")
+
+		origin := c.GetHeader("Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		allowed := allowedOrigin(policy, origin)
+		if allowed == "" {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		c.Header("Access-Control-Allow-Origin", allowed)
+		if policy.AllowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+
+		if preflight {
+			c.Writer.Header().Add("Vary", "Access-Control-Request-Method")
+			c.Writer.Header().Add("Vary", "Access-Control-Request-Headers")
+			c.Header("Access-Control-Allow-Methods", strings.Join(policy.AllowedMethods, ", "))
+			c.Header("Access-Control-Allow-Headers", strings.Join(policy.AllowedHeaders, ", "))
+			if policy.MaxAge > 0 {
+				c.Header("Access-Control-Max-Age", strconv.Itoa(int(policy.MaxAge.Seconds())))
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		if len(policy.ExposedHeaders) > 0 {
+			c.Header("Access-Control-Expose-Headers", strings.Join(policy.ExposedHeaders, ", "))
+		}
+		c.Next()
+	})
+}
+
+// allowedOrigin returns the Access-Control-Allow-Origin value for origin, or
+// "" when the policy doesn't allow it.
+func allowedOrigin(policy config.CORSPolicy, origin string) string {
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	for _, pattern := range policy.AllowedOrigins {
+		if pattern == "*" {
+			if policy.AllowCredentials {
+				// Rejected by config validation; never pair * with credentials
+				continue
+			}
+			return "*"
+		}
+		if originMatches(pattern, u) {
+			return origin
+		}
+	}
+	return ""
+}
+
+// originMatches reports whether origin matches pattern, an origin such as
+// https://example.com or https://*.example.com. A wildcard matches one or
+// more subdomain labels but not the bare domain.
+func originMatches(pattern string, origin *url.URL) bool {
+	p, err := url.Parse(pattern)
+	if err != nil || !strings.EqualFold(p.Scheme, origin.Scheme) || p.Port() != origin.Port() {
+		return false
+	}
+	host, patternHost := strings.ToLower(origin.Hostname()), strings.ToLower(p.Hostname())
+	if suffix, ok := strings.CutPrefix(patternHost, "*"); ok {
+		return strings.HasSuffix(host, suffix) && len(host) > len(suffix)
+	}
+	return host == patternHost
+}
@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"backend/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOriginMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		origin  string
+		want    bool
+	}{
+		{"https://example.com", "https://example.com", true},
+		{"https://example.com", "https://EXAMPLE.com", true},
+		{"https://example.com", "http://example.com", false},
+		{"https://example.com", "https://example.com:8443", false},
+		{"https://example.com:8443", "https://example.com:8443", true},
+		{"https://example.com", "https://app.example.com", false},
+		{"https://*.example.com", "https://app.example.com", true},
+		{"https://*.example.com", "https://a.b.example.com", true},
+		{"https://*.example.com", "https://example.com", false},
+		{"https://*.example.com", "https://badexample.com", false},
+		{"https://*.example.com", "https://app.example.com.evil.io", false},
+		{"https://*.example.com", "http://app.example.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.origin, func(t *testing.T) {
+			origin, err := url.Parse(tt.origin)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := originMatches(tt.pattern, origin); got != tt.want {
+				t.Errorf("originMatches(%q, %q) = %v, want %v", tt.pattern, tt.origin, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowedOrigin(t *testing.T) {
+	listed := []string{"https://app.example.com", "https://*.example.org"}
+
+	tests := []struct {
+		name        string
+		origins     []string
+		credentials bool
+		origin      string
+		want        string
+	}{
+		{"listed origin is reflected", listed, false, "https://app.example.com", "https://app.example.com"},
+		{"each origin gets its own", listed, false, "https://shop.example.org", "https://shop.example.org"},
+		{"unlisted origin", listed, false, "https://example.net", ""},
+		{"malformed origin", listed, false, "null", ""},
+		{"any origin", []string{"*"}, false, "https://example.net", "*"},
+		{"any origin with credentials", []string{"*"}, true, "https://example.net", ""},
+		{"listed origin with credentials", append([]string{"*"}, listed...), true, "https://app.example.com", "https://app.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := config.CORSPolicy{AllowedOrigins: tt.origins, AllowCredentials: tt.credentials}
+			if got := allowedOrigin(policy, tt.origin); got != tt.want {
+				t.Errorf("allowedOrigin(%q) = %q, want %q", tt.origin, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCORS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		args    []string
+		method  string
+		origin  string
+		status  int
+		headers map[string]string
+	}{
+		{
+			name:   "simple request from a listed origin",
+			args:   []string{"--cors-allowed-origins=https://*.example.com"},
+			method: http.MethodGet, origin: "https://app.example.com", status: http.StatusOK,
+			headers: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Allow-Credentials": "",
+				"Access-Control-Expose-Headers":    "ETag, X-Request-ID, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy, Retry-After",
+				"Vary":                             "Origin",
+			},
+		},
+		{
+			name:   "simple request from another origin",
+			args:   []string{"--cors-allowed-origins=https://*.example.com"},
+			method: http.MethodGet, origin: "https://example.net", status: http.StatusOK,
+			headers: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:   "credentials",
+			args:   []string{"--cors-allowed-origins=https://app.example.com", "--cors-allow-credentials=true"},
+			method: http.MethodGet, origin: "https://app.example.com", status: http.StatusOK,
+			headers: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Allow-Credentials": "true",
+			},
+		},
+		{
+			name:   "preflight with the defaults",
+			method: http.MethodOptions, origin: "https://example.net", status: http.StatusNoContent,
+			headers: map[string]string{
+				"Access-Control-Allow-Origin":  "*",
+				"Access-Control-Allow-Methods": "GET, POST, PUT, PATCH, DELETE, OPTIONS",
+				"Access-Control-Max-Age":       "600",
+			},
+		},
+		{
+			name:   "preflight max age",
+			args:   []string{"--cors-max-age=1h"},
+			method: http.MethodOptions, origin: "https://example.net", status: http.StatusNoContent,
+			headers: map[string]string{"Access-Control-Max-Age": "3600"},
+		},
+		{
+			name:   "preflight without max age",
+			args:   []string{"--cors-max-age=0s"},
+			method: http.MethodOptions, origin: "https://example.net", status: http.StatusNoContent,
+			headers: map[string]string{"Access-Control-Max-Age": ""},
+		},
+		{
+			name:   "preflight from another origin",
+			args:   []string{"--cors-allowed-origins=https://app.example.com"},
+			method: http.MethodOptions, origin: "https://example.net", status: http.StatusForbidden,
+			headers: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadArgs(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			router := gin.New()
+			router.Use(CORS(cfg))
+			router.Any("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(tt.method, "/", nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.method == http.MethodOptions {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("status %d, want %d", w.Code, tt.status)
+			}
+			for name, want := range tt.headers {
+				if got := strings.Join(w.Header().Values(name), ", "); got != want {
+					t.Errorf("%s is %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
@@ -1,35 +1,122 @@
 package middleware
 
 import (
+	"crypto/subtle"
+	"log/slog"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/internal/delivery/http/handler"
+	"backend/internal/domain"
+	"backend/internal/logging"
+	"backend/internal/metrics"
+	"backend/internal/requestid"
+
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Context keys other middlewares and handlers read from the gin context.
+const (
+	// RequestIDKey holds the ID RequestID assigned to the request
+	RequestIDKey = "request_id"
+	// UserIDKey holds the authenticated user's ID, when there is one
+	UserIDKey = "user_id"
 )
 
-func CORS() gin.HandlerFunc {
+// RequestID middleware gives each request an ID, reusing a valid
+// X-Request-ID sent by the client and generating one otherwise. The ID is
+// echoed in the response header and stored in both the gin and the request
+// context.
+func RequestID() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		id := c.GetHeader(requestid.Header)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+		c.Set(RequestIDKey, id)
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+		c.Next()
+	})
+}
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
+// abortWithError ends the request with an ErrorResponse.
+func abortWithError(c *gin.Context, status int, code, message string) {
+	handler.RespondError(c, status, handler.ErrorResponse{
+		Error:   code,
+		Message: message,
+	})
+	c.Abort()
+}
+
+// Logger puts a logger carrying the request ID, method, route and trace ID
+// into the request context and logs every request once it completes, at warn
+// level for 4xx answers and error level for 5xx.
+func Logger() gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		start := time.Now()
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		logger := slog.Default().With(
+			"request_id", c.GetString(RequestIDKey),
+			"method", c.Request.Method,
+			"route", route,
+		)
+		if span := trace.SpanContextFromContext(c.Request.Context()); span.IsValid() {
+			logger = logger.With("trace_id", span.TraceID().String())
 		}
+		c.Request = c.Request.WithContext(logging.WithLogger(c.Request.Context(), logger))
 
 		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []slog.Attr{
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int("bytes", max(c.Writer.Size(), 0)),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if userID := c.GetString(UserIDKey); userID != "" {
+			attrs = append(attrs, slog.String("user_id", userID))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", c.Errors.String()))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		logger.LogAttrs(c.Request.Context(), level, "request completed", attrs...)
 	})
 }
 
-// RequestID middleware adds a request ID to each request
-func RequestID() gin.HandlerFunc {
+// Metrics records the count and latency of requests by method, route and
+// status, and how many are in flight.
+func Metrics() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
-		// Simple request ID - in production use proper UUID
-		requestID := c.GetHeader("X-Request-ID")
-		if requestID == "" {
-			requestID = "req-" + c.GetString("gin.request.id")
-		}
-		c.Header("X-Request-ID", requestID)
+		start := time.Now()
+		metrics.HTTPRequestsInFlight.Inc()
+		defer metrics.HTTPRequestsInFlight.Dec()
+
 		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+		metrics.HTTPRequests.WithLabelValues(c.Request.Method, route, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
 	})
 }
 
@@ -38,13 +125,46 @@ func ErrorHandler() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
-				c.JSON(500, gin.H{
-					"error":   "internal_server_error",
-					"message": "An internal server error occurred",
-				})
-				c.Abort()
+				logging.FromContext(c.Request.Context()).Error("panic recovered",
+					"panic", err,
+					"stack", string(debug.Stack()),
+				)
+				abortWithError(c, 500, "internal_server_error", "An internal server error occurred")
 			}
 		}()
 		c.Next()
 	})
 }
+
+// AdminAuth middleware guards admin endpoints with a static bearer token.
+// An empty token disables the admin API entirely.
+func AdminAuth(token string) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if token == "" {
+			abortWithError(c, 403, "admin_disabled", "Admin API is disabled")
+			return
+		}
+
+		provided := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			abortWithError(c, 401, "unauthorized", "Invalid admin token")
+			return
+		}
+
+		c.Next()
+	})
+}
+
+// RequireStorage rejects requests with 503 while the database is unavailable,
+// so endpoints that can't work without it fail fast in degraded mode.
+func RequireStorage(storage domain.StorageStatus) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if !storage.Available() {
+			c.Header("Retry-After", "30")
+			abortWithError(c, 503, "database_unavailable", "The database is unavailable, try again later")
+			return
+		}
+
+		c.Next()
+	})
+}
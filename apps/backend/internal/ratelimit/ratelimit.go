@@ -0,0 +1,116 @@
+// Package ratelimit keeps a token bucket per client and route group.
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"backend/config"
+)
+
+// maxBuckets bounds memory; full buckets are dropped once it is reached,
+// as a full bucket is the same as no bucket.
+const maxBuckets = 100000
+
+// Decision is the outcome of one request against its bucket.
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Period    time.Duration
+	Remaining int
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is how long until the next request is allowed, zero when
+	// this one was
+	RetryAfter time.Duration
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// refill adds the tokens earned since the last update at limit's rate.
+func (b *bucket) refill(now time.Time, limit config.RateLimit) {
+	rate := float64(limit.Requests) / limit.Period.Seconds()
+	b.tokens = math.Min(float64(limit.Requests), b.tokens+now.Sub(b.updated).Seconds()*rate)
+	b.updated = now
+}
+
+type bucketKey struct {
+	group, client string
+}
+
+// Limiter applies the limits in the reloadable RATE_LIMITS setting, read on
+// every request so a reload takes effect at once.
+type Limiter struct {
+	cfg *config.Config
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*bucket
+}
+
+func NewLimiter(cfg *config.Config) *Limiter {
+	return &Limiter{cfg: cfg, buckets: make(map[bucketKey]*bucket)}
+}
+
+// Allow takes a token from client's bucket for group. ok is false when the
+// group has no limit.
+func (l *Limiter) Allow(group, client string) (decision Decision, ok bool) {
+	limit, ok := l.cfg.Runtime().RateLimits[group]
+	if !ok {
+		return Decision{}, false
+	}
+	now := time.Now()
+	rate := float64(limit.Requests) / limit.Period.Seconds()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := bucketKey{group, client}
+	b, found := l.buckets[key]
+	if !found {
+		l.makeRoom(now)
+		b = &bucket{tokens: float64(limit.Requests), updated: now}
+		l.buckets[key] = b
+	}
+	b.refill(now, limit)
+
+	decision = Decision{Limit: limit.Requests, Period: limit.Period}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = seconds((1 - b.tokens) / rate)
+	}
+	decision.Remaining = int(b.tokens)
+	decision.Reset = seconds((float64(limit.Requests) - b.tokens) / rate)
+	return decision, true
+}
+
+// makeRoom drops full buckets once there are too many, and everything if
+// that isn't enough.
+func (l *Limiter) makeRoom(now time.Time) {
+	if len(l.buckets) < maxBuckets {
+		return
+	}
+	limits := l.cfg.Runtime().RateLimits
+	for key, b := range l.buckets {
+		limit, ok := limits[key.group]
+		if !ok {
+			delete(l.buckets, key)
+			continue
+		}
+		b.refill(now, limit)
+		if b.tokens >= float64(limit.Requests) {
+			delete(l.buckets, key)
+		}
+	}
+	if len(l.buckets) >= maxBuckets {
+		l.buckets = make(map[bucketKey]*bucket)
+	}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,351 @@
+package usecase
+
+import (
+	"context"
+
+	"backend/internal/domain"
+	"backend/internal/logging"
+)
+
+// CatalogAdminUseCase lets operators curate stored titles. Edits are kept as
+// overrides so refreshing a title from TMDB doesn't lose them.
+type CatalogAdminUseCase struct {
+	movieRepo    domain.MovieRepository
+	tvShowRepo   domain.TVShowRepository
+	overrideRepo domain.OverrideRepository
+	tmdbService  domain.TMDBService
+}
+
+func NewCatalogAdminUseCase(
+	movieRepo domain.MovieRepository,
+	tvShowRepo domain.TVShowRepository,
+	overrideRepo domain.OverrideRepository,
+	tmdbService domain.TMDBService,
+) *CatalogAdminUseCase {
+	return &CatalogAdminUseCase{
+		movieRepo:    movieRepo,
+		tvShowRepo:   tvShowRepo,
+		overrideRepo: overrideRepo,
+		tmdbService:  tmdbService,
+	}
+}
+
+// GetMovie returns a stored movie, hidden or not, with its override applied.
+func (uc *CatalogAdminUseCase) GetMovie(ctx context.Context, id string) (*domain.Movie, *domain.CatalogOverride, error) {
+	ctx, span := tracer.Start(ctx, "CatalogAdminUseCase.GetMovie")
+	defer span.End()
+
+	movie, err := uc.movieRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, notFoundAs(id, err, ErrMovieNotFound)
+	}
+
+	override, err := uc.overrideRepo.Get(ctx, "movie", movie.TMDBMovieID)
+	if err != nil {
+		return nil, nil, err
+	}
+	movie.ApplyOverride(override)
+
+	return movie, override, nil
+}
+
+// EditMovie applies patch to the movie's override and writes the curated
+// values to the stored movie. Fields the patch sets to null go back to their
+// TMDB values, and an override left empty is dropped. domain.ErrVersionConflict
+// is returned when the stored movie doesn't meet expected.
+func (uc *CatalogAdminUseCase) EditMovie(ctx context.Context, id string, expected domain.VersionCondition, patch domain.OverridePatch) (*domain.Movie, *domain.CatalogOverride, error) {
+	ctx, span := tracer.Start(ctx, "CatalogAdminUseCase.EditMovie")
+	defer span.End()
+
+	if patch.Empty() {
+		return nil, nil, ErrInvalidInput
+	}
+
+	movie, override, err := uc.GetMovie(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !expected.Matches(movie.Version) {
+		return nil, nil, domain.ErrVersionConflict
+	}
+	if override == nil {
+		override = &domain.CatalogOverride{MediaType: "movie", TMDBID: movie.TMDBMovieID}
+	}
+	before := *movie
+
+	// A dropped curated value falls back to upstream, which only TMDB still
+	// has: the stored movie carries the curated values
+	override.Fields.Apply(patch)
+	if patch.Clears() {
+		upstream, err := uc.tmdbService.GetMovie(ctx, movie.TMDBMovieID)
+		if err != nil {
+			return nil, nil, err
+		}
+		movie.ApplyUpstream(upstream)
+	}
+	movie.ApplyOverride(override)
+
+	// The versioned update goes first so a conflicting edit never reaches
+	// the stored override; it is undone if the override can't be written
+	if err := uc.movieRepo.Update(ctx, movie); err != nil {
+		return nil, nil, err
+	}
+	if override.Fields == (domain.OverrideFields{}) {
+		if err := uc.overrideRepo.Delete(ctx, "movie", movie.TMDBMovieID); err != nil {
+			uc.rollbackMovie(ctx, &before, movie)
+			return nil, nil, err
+		}
+		return movie, nil, nil
+	}
+	if err := uc.overrideRepo.Save(ctx, override); err != nil {
+		uc.rollbackMovie(ctx, &before, movie)
+		return nil, nil, err
+	}
+
+	return movie, override, nil
+}
+
+// ResetMovie drops the movie's override and restores the upstream values
+// from TMDB. The override is kept when the movie can't be updated.
+func (uc *CatalogAdminUseCase) ResetMovie(ctx context.Context, id string) (*domain.Movie, error) {
+	ctx, span := tracer.Start(ctx, "CatalogAdminUseCase.ResetMovie")
+	defer span.End()
+
+	movie, err := uc.movieRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, notFoundAs(id, err, ErrMovieNotFound)
+	}
+
+	upstream, err := uc.tmdbService.GetMovie(ctx, movie.TMDBMovieID)
+	if err != nil {
+		return nil, err
+	}
+
+	// As in EditMovie, the versioned update goes first so a conflict
+	// leaves the override in place, and is undone if the override can't be
+	// dropped
+	before := *movie
+	movie.ApplyUpstream(upstream)
+	if err := uc.movieRepo.Update(ctx, movie); err != nil {
+		return nil, err
+	}
+	if err := uc.overrideRepo.Delete(ctx, "movie", movie.TMDBMovieID); err != nil {
+		uc.rollbackMovie(ctx, &before, movie)
+		return nil, err
+	}
+
+	return movie, nil
+}
+
+// rollbackMovie puts the movie back as it was before updated was written,
+// after the override write that had to follow failed.
+func (uc *CatalogAdminUseCase) rollbackMovie(ctx context.Context, before, updated *domain.Movie) {
+	before.Version = updated.Version
+	if err := uc.movieRepo.Update(ctx, before); err != nil {
+		logging.FromContext(ctx).Error("Failed to roll back movie after its override write failed", "id", before.ID.Hex(), "error", err)
+	}
+}
+
+// SetMovieHidden hides a movie from every public listing, or shows it again.
+func (uc *CatalogAdminUseCase) SetMovieHidden(ctx context.Context, id string, hidden bool) (*domain.Movie, error) {
+	ctx, span := tracer.Start(ctx, "CatalogAdminUseCase.SetMovieHidden")
+	defer span.End()
+
+	movie, _, err := uc.GetMovie(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	movie.Hidden = hidden
+	if err := uc.movieRepo.Update(ctx, movie); err != nil {
+		return nil, err
+	}
+
+	return movie, nil
+}
+
+// DeleteMovie soft-deletes a movie. It is kept, out of every listing, until
+// restored or purged.
+func (uc *CatalogAdminUseCase) DeleteMovie(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "CatalogAdminUseCase.DeleteMovie")
+	defer span.End()
+
+	if err := uc.movieRepo.Delete(ctx, id); err != nil {
+		return notFoundAs(id, err, ErrMovieNotFound)
+	}
+	return nil
+}
+
+// RestoreMovie brings back a soft-deleted movie.
+func (uc *CatalogAdminUseCase) RestoreMovie(ctx context.Context, id string) (*domain.Movie, error) {
+	ctx, span := tracer.Start(ctx, "CatalogAdminUseCase.RestoreMovie")
+	defer span.End()
+
+	if err := uc.movieRepo.Restore(ctx, id); err != nil {
+		return nil, notFoundAs(id, err, ErrMovieNotFound)
+	}
+
+	movie, _, err := uc.GetMovie(ctx, id)
+	return movie, err
+}
+
+// GetTVShow returns a stored TV show, hidden or not, with its override
+// applied.
+func (uc *CatalogAdminUseCase) GetTVShow(ctx context.Context, id string) (*domain.TVShow, *domain.CatalogOverride, error) {
+	ctx, span := tracer.Start(ctx, "CatalogAdminUseCase.GetTVShow")
+	defer span.End()
+
+	tvShow, err := uc.tvShowRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, notFoundAs(id, err, ErrTVShowNotFound)
+	}
+
+	override, err := uc.overrideRepo.Get(ctx, "tv", tvShow.TMDBTVShowID)
+	if err != nil {
+		return nil, nil, err
+	}
+	tvShow.ApplyOverride(override)
+
+	return tvShow, override, nil
+}
+
+// EditTVShow applies patch to the TV show's override and writes the curated
+// values to the stored TV show. Fields the patch sets to null go back to
+// their TMDB values, and an override left empty is dropped.
+// domain.ErrVersionConflict is returned when the stored TV show doesn't meet
+// expected.
+func (uc *CatalogAdminUseCase) EditTVShow(ctx context.Context, id string, expected domain.VersionCondition, patch domain.OverridePatch) (*domain.TVShow, *domain.CatalogOverride, error) {
+	ctx, span := tracer.Start(ctx, "CatalogAdminUseCase.EditTVShow")
+	defer span.End()
+
+	if patch.Empty() {
+		return nil, nil, ErrInvalidInput
+	}
+
+	tvShow, override, err := uc.GetTVShow(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !expected.Matches(tvShow.Version) {
+		return nil, nil, domain.ErrVersionConflict
+	}
+	if override == nil {
+		override = &domain.CatalogOverride{MediaType: "tv", TMDBID: tvShow.TMDBTVShowID}
+	}
+	before := *tvShow
+
+	// A dropped curated value falls back to upstream, which only TMDB still
+	// has: the stored TV show carries the curated values
+	override.Fields.Apply(patch)
+	if patch.Clears() {
+		upstream, err := uc.tmdbService.GetTVShow(ctx, tvShow.TMDBTVShowID)
+		if err != nil {
+			return nil, nil, err
+		}
+		tvShow.ApplyUpstream(upstream)
+	}
+	tvShow.ApplyOverride(override)
+
+	// The versioned update goes first so a conflicting edit never reaches
+	// the stored override; it is undone if the override can't be written
+	if err := uc.tvShowRepo.Update(ctx, tvShow); err != nil {
+		return nil, nil, err
+	}
+	if override.Fields == (domain.OverrideFields{}) {
+		if err := uc.overrideRepo.Delete(ctx, "tv", tvShow.TMDBTVShowID); err != nil {
+			uc.rollbackTVShow(ctx, &before, tvShow)
+			return nil, nil, err
+		}
+		return tvShow, nil, nil
+	}
+	if err := uc.overrideRepo.Save(ctx, override); err != nil {
+		uc.rollbackTVShow(ctx, &before, tvShow)
+		return nil, nil, err
+	}
+
+	return tvShow, override, nil
+}
+
+// ResetTVShow drops the TV show's override and restores the upstream values
+// from TMDB. The override is kept when the TV show can't be updated.
+func (uc *CatalogAdminUseCase) ResetTVShow(ctx context.Context, id string) (*domain.TVShow, error) {
+	ctx, span := tracer.Start(ctx, "CatalogAdminUseCase.ResetTVShow")
+	defer span.End()
+
+	tvShow, err := uc.tvShowRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, notFoundAs(id, err, ErrTVShowNotFound)
+	}
+
+	upstream, err := uc.tmdbService.GetTVShow(ctx, tvShow.TMDBTVShowID)
+	if err != nil {
+		return nil, err
+	}
+
+	// As in EditTVShow, the versioned update goes first so a conflict
+	// leaves the override in place, and is undone if the override can't be
+	// dropped
+	before := *tvShow
+	tvShow.ApplyUpstream(upstream)
+	if err := uc.tvShowRepo.Update(ctx, tvShow); err != nil {
+		return nil, err
+	}
+	if err := uc.overrideRepo.Delete(ctx, "tv", tvShow.TMDBTVShowID); err != nil {
+		uc.rollbackTVShow(ctx, &before, tvShow)
+		return nil, err
+	}
+
+	return tvShow, nil
+}
+
+// rollbackTVShow is rollbackMovie for TV shows.
+func (uc *CatalogAdminUseCase) rollbackTVShow(ctx context.Context, before, updated *domain.TVShow) {
+	before.Version = updated.Version
+	if err := uc.tvShowRepo.Update(ctx, before); err != nil {
+		logging.FromContext(ctx).Error("Failed to roll back TV show after its override write failed", "id", before.ID.Hex(), "error", err)
+	}
+}
+
+// SetTVShowHidden hides a TV show from every public listing, or shows it
+// again.
+func (uc *CatalogAdminUseCase) SetTVShowHidden(ctx context.Context, id string, hidden bool) (*domain.TVShow, error) {
+	ctx, span := tracer.Start(ctx, "CatalogAdminUseCase.SetTVShowHidden")
+	defer span.End()
+
+	tvShow, _, err := uc.GetTVShow(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tvShow.Hidden = hidden
+	if err := uc.tvShowRepo.Update(ctx, tvShow); err != nil {
+		return nil, err
+	}
+
+	return tvShow, nil
+}
+
+// DeleteTVShow soft-deletes a TV show. It is kept, out of every listing,
+// until restored or purged.
+func (uc *CatalogAdminUseCase) DeleteTVShow(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "CatalogAdminUseCase.DeleteTVShow")
+	defer span.End()
+
+	if err := uc.tvShowRepo.Delete(ctx, id); err != nil {
+		return notFoundAs(id, err, ErrTVShowNotFound)
+	}
+	return nil
+}
+
+// RestoreTVShow brings back a soft-deleted TV show.
+func (uc *CatalogAdminUseCase) RestoreTVShow(ctx context.Context, id string) (*domain.TVShow, error) {
+	ctx, span := tracer.Start(ctx, "CatalogAdminUseCase.RestoreTVShow")
+	defer span.End()
+
+	if err := uc.tvShowRepo.Restore(ctx, id); err != nil {
+		return nil, notFoundAs(id, err, ErrTVShowNotFound)
+	}
+
+	tvShow, _, err := uc.GetTVShow(ctx, id)
+	return tvShow, err
+}
@@ -0,0 +1,269 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"backend/internal/domain"
+	"backend/internal/infrastructure/repository/memory"
+	"backend/internal/usecase"
+)
+
+// fakeTMDB serves a fixed set of movies as both the popular list and their
+// details.
+type fakeTMDB struct {
+	domain.TMDBService
+	movies map[int]domain.Movie
+}
+
+func (f *fakeTMDB) GetMovie(ctx context.Context, movieID int) (*domain.Movie, error) {
+	movie, ok := f.movies[movieID]
+	if !ok {
+		return nil, errors.New("not on TMDB")
+	}
+	return &movie, nil
+}
+
+func (f *fakeTMDB) GetPopularMovies(ctx context.Context, page int) ([]*domain.Movie, int, error) {
+	movies := make([]*domain.Movie, 0, len(f.movies))
+	for _, movie := range f.movies {
+		movies = append(movies, &movie)
+	}
+	return movies, 1, nil
+}
+
+type storageAvailable struct{}
+
+func (storageAvailable) Available() bool { return true }
+
+type catalogFixture struct {
+	repos  domain.Repositories
+	movies *usecase.MovieUseCase
+	admin  *usecase.CatalogAdminUseCase
+	stored *domain.Movie
+}
+
+// newCatalogFixture stores one movie through a popular list refresh.
+func newCatalogFixture(t *testing.T) *catalogFixture {
+	t.Helper()
+	tmdb := &fakeTMDB{movies: map[int]domain.Movie{
+		550: {TMDBMovieID: 550, Title: "Fight Club", Tagline: "Mischief. Mayhem. Soap.", Runtime: 139},
+	}}
+	repos := memory.New()
+	f := &catalogFixture{
+		repos:  repos,
+		movies: usecase.NewMovieUseCase(repos.Movies, repos.Overrides, tmdb, storageAvailable{}),
+		admin:  usecase.NewCatalogAdminUseCase(repos.Movies, repos.TVShows, repos.Overrides, tmdb),
+	}
+
+	if _, _, err := f.movies.GetPopularMovies(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := repos.Movies.GetByTMDBID(context.Background(), 550)
+	if err != nil {
+		t.Fatalf("popular list wasn't stored: %v", err)
+	}
+	f.stored = stored
+	return f
+}
+
+// edit applies a JSON merge patch to the stored movie at its current version.
+func (f *catalogFixture) edit(t *testing.T, patchJSON string) (*domain.Movie, *domain.CatalogOverride) {
+	t.Helper()
+	var patch domain.OverridePatch
+	if err := json.Unmarshal([]byte(patchJSON), &patch); err != nil {
+		t.Fatal(err)
+	}
+	current, err := f.repos.Movies.GetByID(context.Background(), f.stored.ID.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	movie, override, err := f.admin.EditMovie(context.Background(), f.stored.ID.Hex(), domain.VersionCondition{Versions: []int64{current.Version}}, patch)
+	if err != nil {
+		t.Fatalf("EditMovie(%s): %v", patchJSON, err)
+	}
+	return movie, override
+}
+
+func TestEditMovieSurvivesListRefresh(t *testing.T) {
+	f := newCatalogFixture(t)
+	ctx := context.Background()
+
+	edited, _ := f.edit(t, `{"title": "Fight Club (Director's Cut)"}`)
+	if edited.Title != "Fight Club (Director's Cut)" || edited.Runtime != 139 {
+		t.Fatalf("edited movie is %q with runtime %d", edited.Title, edited.Runtime)
+	}
+
+	movies, _, err := f.movies.GetPopularMovies(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(movies) != 1 || movies[0].Title != "Fight Club (Director's Cut)" {
+		t.Fatalf("popular list served %+v", movies)
+	}
+	stored, err := f.repos.Movies.GetByTMDBID(ctx, 550)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Title != "Fight Club (Director's Cut)" {
+		t.Errorf("refresh stored the upstream title %q", stored.Title)
+	}
+	if stored.Version != edited.Version {
+		t.Errorf("unchanged refresh moved the version from %d to %d", edited.Version, stored.Version)
+	}
+}
+
+func TestEditMovieNullDropsCuratedValue(t *testing.T) {
+	f := newCatalogFixture(t)
+
+	f.edit(t, `{"title": "Curated", "tagline": "Curated tagline"}`)
+	movie, override := f.edit(t, `{"title": null}`)
+	if movie.Title != "Fight Club" || movie.Tagline != "Curated tagline" {
+		t.Fatalf("dropping the title left %q / %q", movie.Title, movie.Tagline)
+	}
+	if override == nil || override.Fields.Title != nil {
+		t.Fatalf("override still curates the title: %+v", override)
+	}
+
+	movie, override = f.edit(t, `{"tagline": null}`)
+	if movie.Tagline != "Mischief. Mayhem. Soap." || override != nil {
+		t.Fatalf("dropping the last value left tagline %q and override %+v", movie.Tagline, override)
+	}
+	if stored, err := f.repos.Overrides.Get(context.Background(), "movie", 550); err != nil || stored != nil {
+		t.Fatalf("empty override still stored: %+v, %v", stored, err)
+	}
+}
+
+func TestEditMovieVersionCondition(t *testing.T) {
+	f := newCatalogFixture(t)
+	ctx := context.Background()
+	patch := domain.OverridePatch{Tagline: domain.PatchField[string]{Set: true, Value: new(string)}}
+
+	stale := domain.VersionCondition{Versions: []int64{f.stored.Version + 1}}
+	if _, _, err := f.admin.EditMovie(ctx, f.stored.ID.Hex(), stale, patch); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("edit on a stale version returned %v, want a version conflict", err)
+	}
+	if _, _, err := f.admin.EditMovie(ctx, f.stored.ID.Hex(), domain.VersionCondition{Any: true}, patch); err != nil {
+		t.Fatalf("edit on any version: %v", err)
+	}
+	if _, _, err := f.admin.EditMovie(ctx, f.stored.ID.Hex(), domain.VersionCondition{Any: true}, domain.OverridePatch{}); !errors.Is(err, usecase.ErrInvalidInput) {
+		t.Fatalf("empty edit returned %v, want invalid input", err)
+	}
+}
+
+// brokenOverrides fails every override write.
+type brokenOverrides struct {
+	domain.OverrideRepository
+}
+
+var errOverrideWrite = errors.New("override write failed")
+
+func (brokenOverrides) Save(ctx context.Context, override *domain.CatalogOverride) error {
+	return errOverrideWrite
+}
+
+func (brokenOverrides) Delete(ctx context.Context, mediaType string, tmdbID int) error {
+	return errOverrideWrite
+}
+
+func TestFailedOverrideWriteRollsBackMovie(t *testing.T) {
+	f := newCatalogFixture(t)
+	ctx := context.Background()
+	f.edit(t, `{"title": "Curated"}`)
+	before, err := f.repos.Movies.GetByID(ctx, f.stored.ID.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmdb := &fakeTMDB{movies: map[int]domain.Movie{550: {TMDBMovieID: 550, Title: "Fight Club"}}}
+	broken := usecase.NewCatalogAdminUseCase(f.repos.Movies, f.repos.TVShows, brokenOverrides{f.repos.Overrides}, tmdb)
+
+	tagline := "Edited"
+	patch := domain.OverridePatch{Tagline: domain.PatchField[string]{Set: true, Value: &tagline}}
+	if _, _, err := broken.EditMovie(ctx, f.stored.ID.Hex(), domain.VersionCondition{Any: true}, patch); !errors.Is(err, errOverrideWrite) {
+		t.Fatalf("EditMovie returned %v, want the override write error", err)
+	}
+	if _, err := broken.ResetMovie(ctx, f.stored.ID.Hex()); !errors.Is(err, errOverrideWrite) {
+		t.Fatalf("ResetMovie returned %v, want the override write error", err)
+	}
+
+	stored, err := f.repos.Movies.GetByID(ctx, f.stored.ID.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Title != "Curated" || stored.Tagline != before.Tagline {
+		t.Errorf("failed writes left the movie at %q / %q, want %q / %q", stored.Title, stored.Tagline, "Curated", before.Tagline)
+	}
+	if override, err := f.repos.Overrides.Get(ctx, "movie", 550); err != nil || override == nil || override.Fields.Title == nil {
+		t.Errorf("failed reset lost the override: %+v, %v", override, err)
+	}
+}
+
+func TestResetMovieConflictKeepsOverride(t *testing.T) {
+	f := newCatalogFixture(t)
+	ctx := context.Background()
+	f.edit(t, `{"title": "Curated"}`)
+
+	// The movie moves on between the reset's read and its write
+	racing := &racingMovies{MovieRepository: f.repos.Movies}
+	admin := usecase.NewCatalogAdminUseCase(racing, f.repos.TVShows, f.repos.Overrides, &fakeTMDB{movies: map[int]domain.Movie{
+		550: {TMDBMovieID: 550, Title: "Fight Club"},
+	}})
+	if _, err := admin.ResetMovie(ctx, f.stored.ID.Hex()); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("ResetMovie returned %v, want a version conflict", err)
+	}
+	if override, err := f.repos.Overrides.Get(ctx, "movie", 550); err != nil || override == nil {
+		t.Errorf("conflicting reset dropped the override: %+v, %v", override, err)
+	}
+}
+
+// racingMovies bumps a movie's stored version right after it is read.
+type racingMovies struct {
+	domain.MovieRepository
+}
+
+func (r *racingMovies) GetByID(ctx context.Context, id string) (*domain.Movie, error) {
+	movie, err := r.MovieRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	concurrent := *movie
+	if err := r.MovieRepository.Update(ctx, &concurrent); err != nil {
+		return nil, err
+	}
+	return movie, nil
+}
+
+func TestGetMovieByTMDBIDStoresCuratedValues(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.New()
+	tmdb := &fakeTMDB{movies: map[int]domain.Movie{603: {TMDBMovieID: 603, Title: "The Matrix"}}}
+	movies := usecase.NewMovieUseCase(repos.Movies, repos.Overrides, tmdb, storageAvailable{})
+
+	// An override saved before the title is stored
+	title := "The Matrix (Remastered)"
+	if err := repos.Overrides.Save(ctx, &domain.CatalogOverride{
+		MediaType: "movie",
+		TMDBID:    603,
+		Fields:    domain.OverrideFields{Title: &title},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	movie, err := movies.GetMovieByTMDBID(ctx, 603)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if movie.Title != title {
+		t.Errorf("served title %q, want %q", movie.Title, title)
+	}
+	stored, err := repos.Movies.GetByTMDBID(ctx, 603)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Title != title {
+		t.Errorf("stored title %q, want %q", stored.Title, title)
+	}
+}
@@ -3,45 +3,77 @@ package usecase
 import (
 	"context"
 	"errors"
-	"time"
+	"strings"
 
 	"backend/internal/domain"
+	"backend/internal/logging"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 var (
 	ErrMovieNotFound  = errors.New("movie not found")
 	ErrTVShowNotFound = errors.New("tv show not found")
+	ErrPersonNotFound = errors.New("person not found")
 	ErrUserNotFound   = errors.New("user not found")
 	ErrInvalidInput   = errors.New("invalid input")
 	ErrAlreadyExists  = errors.New("already exists")
 	ErrUnauthorized   = errors.New("unauthorized")
 )
 
+// notFoundAs reports err as notFound when the record with the given ID
+// doesn't exist or the ID can't name one. Any other error is returned as is.
+func notFoundAs(id string, err, notFound error) error {
+	if errors.Is(err, domain.ErrNotFound) || !primitive.IsValidObjectID(id) {
+		return notFound
+	}
+	return err
+}
+
 type MovieUseCase struct {
-	movieRepo   domain.MovieRepository
-	tmdbService domain.TMDBService
+	movieRepo    domain.MovieRepository
+	overrideRepo domain.OverrideRepository
+	tmdbService  domain.TMDBService
+	storage      domain.StorageStatus
 }
 
-func NewMovieUseCase(movieRepo domain.MovieRepository, tmdbService domain.TMDBService) *MovieUseCase {
+func NewMovieUseCase(movieRepo domain.MovieRepository, overrideRepo domain.OverrideRepository, tmdbService domain.TMDBService, storage domain.StorageStatus) *MovieUseCase {
 	return &MovieUseCase{
-		movieRepo:   movieRepo,
-		tmdbService: tmdbService,
+		movieRepo:    movieRepo,
+		overrideRepo: overrideRepo,
+		tmdbService:  tmdbService,
+		storage:      storage,
 	}
 }
 
 func (uc *MovieUseCase) GetMovieByID(ctx context.Context, id string) (*domain.Movie, error) {
+	ctx, span := tracer.Start(ctx, "MovieUseCase.GetMovieByID")
+	defer span.End()
+
 	movie, err := uc.movieRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, ErrMovieNotFound
 	}
-	return movie, nil
+	return uc.visible(ctx, movie)
 }
 
 func (uc *MovieUseCase) GetMovieByTMDBID(ctx context.Context, tmdbID int) (*domain.Movie, error) {
+	ctx, span := tracer.Start(ctx, "MovieUseCase.GetMovieByTMDBID")
+	defer span.End()
+
+	// Without a database, serve TMDB's copy as is
+	if !uc.storage.Available() {
+		tmdbMovie, err := uc.tmdbService.GetMovie(ctx, tmdbID)
+		if err != nil {
+			return nil, ErrMovieNotFound
+		}
+		return tmdbMovie, nil
+	}
+
 	// First check if we have it in our database
 	movie, err := uc.movieRepo.GetByTMDBID(ctx, tmdbID)
 	if err == nil {
-		return movie, nil
+		return uc.visible(ctx, movie)
 	}
 
 	// If not found locally, fetch from TMDB and store
@@ -50,18 +82,31 @@ func (uc *MovieUseCase) GetMovieByTMDBID(ctx context.Context, tmdbID int) (*doma
 		return nil, ErrMovieNotFound
 	}
 
-	// Save to our database
-	tmdbMovie.CreatedAt = time.Now()
-	tmdbMovie.UpdatedAt = time.Now()
-	if err := uc.movieRepo.Create(ctx, tmdbMovie); err != nil {
-		// Log error but return the movie anyway
-		// TODO: Add proper logging
+	// Curated values are stored too, as in storeMovies; without the
+	// override, storing would overwrite them with upstream ones
+	override, err := uc.overrideRepo.Get(ctx, "movie", tmdbID)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to load catalog override, not storing movie fetched from TMDB", "tmdb_id", tmdbID, "error", err)
+		return tmdbMovie, nil
+	}
+	tmdbMovie.ApplyOverride(override)
+
+	// Save to our database, serving the movie anyway if that fails
+	if err := uc.movieRepo.Upsert(ctx, tmdbMovie); err != nil {
+		logging.FromContext(ctx).Warn("Failed to store movie fetched from TMDB", "tmdb_id", tmdbID, "error", err)
 	}
 
+	// The stored copy may have been hidden or deleted
+	if tmdbMovie.Hidden || tmdbMovie.DeletedAt != nil {
+		return nil, ErrMovieNotFound
+	}
 	return tmdbMovie, nil
 }
 
 func (uc *MovieUseCase) SearchMovies(ctx context.Context, query string, page int) ([]*domain.Movie, int, error) {
+	ctx, span := tracer.Start(ctx, "MovieUseCase.SearchMovies")
+	defer span.End()
+
 	if query == "" {
 		return nil, 0, ErrInvalidInput
 	}
@@ -72,51 +117,109 @@ func (uc *MovieUseCase) SearchMovies(ctx context.Context, query string, page int
 		return nil, 0, err
 	}
 
-	// Store in our database for future reference
-	for _, movie := range movies {
-		movie.CreatedAt = time.Now()
-		movie.UpdatedAt = time.Now()
-		// Check if exists first
-		if _, err := uc.movieRepo.GetByTMDBID(ctx, movie.TMDBMovieID); err != nil {
-			uc.movieRepo.Create(ctx, movie) // Ignore errors for now
-		}
-	}
-
-	return movies, totalPages, nil
+	return uc.storeMovies(ctx, movies), totalPages, nil
 }
 
 func (uc *MovieUseCase) GetPopularMovies(ctx context.Context, page int) ([]*domain.Movie, int, error) {
+	ctx, span := tracer.Start(ctx, "MovieUseCase.GetPopularMovies")
+	defer span.End()
+
 	movies, totalPages, err := uc.tmdbService.GetPopularMovies(ctx, page)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	// Store in our database
-	for _, movie := range movies {
-		movie.CreatedAt = time.Now()
-		movie.UpdatedAt = time.Now()
-		if _, err := uc.movieRepo.GetByTMDBID(ctx, movie.TMDBMovieID); err != nil {
-			uc.movieRepo.Create(ctx, movie)
-		}
-	}
-
-	return movies, totalPages, nil
+	return uc.storeMovies(ctx, movies), totalPages, nil
 }
 
 func (uc *MovieUseCase) GetMoviesByGenre(ctx context.Context, genreID int, page int) ([]*domain.Movie, int, error) {
+	ctx, span := tracer.Start(ctx, "MovieUseCase.GetMoviesByGenre")
+	defer span.End()
+
 	movies, totalPages, err := uc.tmdbService.GetMoviesByGenre(ctx, genreID, page)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	// Store in our database
+	return uc.storeMovies(ctx, movies), totalPages, nil
+}
+
+// SearchCatalog searches movies already stored locally, best matches first.
+func (uc *MovieUseCase) SearchCatalog(ctx context.Context, query string, limit, offset int, withTotal bool) ([]*domain.MovieSearchResult, *int64, error) {
+	ctx, span := tracer.Start(ctx, "MovieUseCase.SearchCatalog")
+	defer span.End()
+
+	if strings.TrimSpace(query) == "" {
+		return nil, nil, ErrInvalidInput
+	}
+	results, total, err := uc.movieRepo.Search(ctx, query, limit, offset, withTotal)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tmdbIDs := make([]int, 0, len(results))
+	for _, result := range results {
+		tmdbIDs = append(tmdbIDs, result.TMDBMovieID)
+	}
+	overrides := loadOverrides(ctx, uc.overrideRepo, "movie", tmdbIDs)
+	for _, result := range results {
+		result.ApplyOverride(overrides[result.TMDBMovieID])
+	}
+
+	return results, total, nil
+}
+
+// BrowseCatalog lists movies already stored locally.
+func (uc *MovieUseCase) BrowseCatalog(ctx context.Context, query domain.CatalogQuery, page domain.PageRequest) (*domain.Page[*domain.Movie], error) {
+	ctx, span := tracer.Start(ctx, "MovieUseCase.BrowseCatalog")
+	defer span.End()
+
+	result, err := uc.movieRepo.Browse(ctx, query, page)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Items = mergeMovies(ctx, uc.overrideRepo, result.Items)
+	return result, nil
+}
+
+// storeMovies saves movies fetched from TMDB for future reference and merges in
+// curated overrides. In degraded mode they are returned as fetched.
+func (uc *MovieUseCase) storeMovies(ctx context.Context, movies []*domain.Movie) []*domain.Movie {
+	if !uc.storage.Available() || len(movies) == 0 {
+		return movies
+	}
+
+	overrides, err := uc.overrideRepo.GetMany(ctx, "movie", movieTMDBIDs(movies))
+	if err != nil {
+		// Storing upstream values now would overwrite curated ones
+		logging.FromContext(ctx).Warn("Failed to load catalog overrides, not storing movies fetched from TMDB", "count", len(movies), "error", err)
+		return overrideMovies(movies, nil)
+	}
+
+	// Curated values are stored too, so local search ranks on them
+	keyed := byTMDBID(overrides)
 	for _, movie := range movies {
-		movie.CreatedAt = time.Now()
-		movie.UpdatedAt = time.Now()
-		if _, err := uc.movieRepo.GetByTMDBID(ctx, movie.TMDBMovieID); err != nil {
-			uc.movieRepo.Create(ctx, movie)
-		}
+		movie.ApplyOverride(keyed[movie.TMDBMovieID])
+	}
+	if err := uc.movieRepo.UpsertMany(ctx, movies); err != nil {
+		logging.FromContext(ctx).Warn("Failed to store movies fetched from TMDB", "count", len(movies), "error", err)
+	}
+	return overrideMovies(movies, keyed)
+}
+
+// visible hides a title an operator has hidden or deleted and applies its
+// curated override.
+func (uc *MovieUseCase) visible(ctx context.Context, movie *domain.Movie) (*domain.Movie, error) {
+	if movie.Hidden || movie.DeletedAt != nil {
+		return nil, ErrMovieNotFound
 	}
 
-	return movies, totalPages, nil
+	override, err := uc.overrideRepo.Get(ctx, "movie", movie.TMDBMovieID)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to load catalog override", "media_type", "movie", "tmdb_id", movie.TMDBMovieID, "error", err)
+	} else {
+		movie.ApplyOverride(override)
+	}
+	return movie, nil
 }
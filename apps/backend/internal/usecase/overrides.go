@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+
+	"backend/internal/domain"
+	"backend/internal/logging"
+)
+
+// loadOverrides fetches the curated overrides for tmdbIDs keyed by TMDB ID.
+// Read paths fall back to upstream data when overrides can't be loaded.
+func loadOverrides(ctx context.Context, repo domain.OverrideRepository, mediaType string, tmdbIDs []int) map[int]*domain.CatalogOverride {
+	if len(tmdbIDs) == 0 {
+		return nil
+	}
+
+	overrides, err := repo.GetMany(ctx, mediaType, tmdbIDs)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to load catalog overrides", "media_type", mediaType, "count", len(tmdbIDs), "error", err)
+		return nil
+	}
+	return byTMDBID(overrides)
+}
+
+func byTMDBID(overrides []*domain.CatalogOverride) map[int]*domain.CatalogOverride {
+	keyed := make(map[int]*domain.CatalogOverride, len(overrides))
+	for _, o := range overrides {
+		keyed[o.TMDBID] = o
+	}
+	return keyed
+}
+
+// mergeMovies drops hidden and deleted movies and applies curated overrides to the rest.
+func mergeMovies(ctx context.Context, repo domain.OverrideRepository, movies []*domain.Movie) []*domain.Movie {
+	return overrideMovies(movies, loadOverrides(ctx, repo, "movie", movieTMDBIDs(movies)))
+}
+
+// overrideMovies drops hidden and deleted movies and applies overrides to
+// the rest.
+func overrideMovies(movies []*domain.Movie, overrides map[int]*domain.CatalogOverride) []*domain.Movie {
+	visible := movies[:0]
+	for _, movie := range movies {
+		if movie.Hidden || movie.DeletedAt != nil {
+			continue
+		}
+		movie.ApplyOverride(overrides[movie.TMDBMovieID])
+		visible = append(visible, movie)
+	}
+	return visible
+}
+
+func movieTMDBIDs(movies []*domain.Movie) []int {
+	tmdbIDs := make([]int, 0, len(movies))
+	for _, movie := range movies {
+		tmdbIDs = append(tmdbIDs, movie.TMDBMovieID)
+	}
+	return tmdbIDs
+}
+
+// mergeTVShows drops hidden and deleted TV shows and applies curated
+// overrides to the rest.
+func mergeTVShows(ctx context.Context, repo domain.OverrideRepository, tvShows []*domain.TVShow) []*domain.TVShow {
+	return overrideTVShows(tvShows, loadOverrides(ctx, repo, "tv", tvShowTMDBIDs(tvShows)))
+}
+
+// overrideTVShows drops hidden and deleted TV shows and applies overrides to
+// the rest.
+func overrideTVShows(tvShows []*domain.TVShow, overrides map[int]*domain.CatalogOverride) []*domain.TVShow {
+	visible := tvShows[:0]
+	for _, tvShow := range tvShows {
+		if tvShow.Hidden || tvShow.DeletedAt != nil {
+			continue
+		}
+		tvShow.ApplyOverride(overrides[tvShow.TMDBTVShowID])
+		visible = append(visible, tvShow)
+	}
+	return visible
+}
+
+func tvShowTMDBIDs(tvShows []*domain.TVShow) []int {
+	tmdbIDs := make([]int, 0, len(tvShows))
+	for _, tvShow := range tvShows {
+		tmdbIDs = append(tmdbIDs, tvShow.TMDBTVShowID)
+	}
+	return tmdbIDs
+}
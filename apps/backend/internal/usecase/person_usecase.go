@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+
+	"backend/internal/domain"
+	"backend/internal/logging"
+)
+
+type PersonUseCase struct {
+	personRepo  domain.PersonRepository
+	tmdbService domain.TMDBService
+	storage     domain.StorageStatus
+}
+
+func NewPersonUseCase(personRepo domain.PersonRepository, tmdbService domain.TMDBService, storage domain.StorageStatus) *PersonUseCase {
+	return &PersonUseCase{
+		personRepo:  personRepo,
+		tmdbService: tmdbService,
+		storage:     storage,
+	}
+}
+
+// GetPersonByTMDBID serves the stored person, fetching and storing them
+// first when they aren't held yet. Stored people are kept fresh by the
+// changes sync.
+func (uc *PersonUseCase) GetPersonByTMDBID(ctx context.Context, tmdbID int) (*domain.Person, error) {
+	ctx, span := tracer.Start(ctx, "PersonUseCase.GetPersonByTMDBID")
+	defer span.End()
+
+	// Without a database, serve TMDB's copy as is
+	if !uc.storage.Available() {
+		tmdbPerson, err := uc.tmdbService.GetPerson(ctx, tmdbID)
+		if err != nil {
+			return nil, ErrPersonNotFound
+		}
+		return tmdbPerson, nil
+	}
+
+	person, err := uc.personRepo.GetByTMDBID(ctx, tmdbID)
+	if err == nil {
+		return person, nil
+	}
+
+	tmdbPerson, err := uc.tmdbService.GetPerson(ctx, tmdbID)
+	if err != nil {
+		return nil, ErrPersonNotFound
+	}
+
+	// Save to our database, serving the person anyway if that fails
+	if err := uc.personRepo.Upsert(ctx, tmdbPerson); err != nil {
+		logging.FromContext(ctx).Warn("Failed to store person fetched from TMDB", "tmdb_id", tmdbID, "error", err)
+	}
+
+	return tmdbPerson, nil
+}
@@ -0,0 +1,6 @@
+package usecase
+
+import "go.opentelemetry.io/otel"
+
+// tracer starts a span for every exported use-case method.
+var tracer = otel.Tracer("backend/internal/usecase")
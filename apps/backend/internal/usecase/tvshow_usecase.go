@@ -2,36 +2,56 @@ package usecase
 
 import (
 	"context"
-	"time"
+	"strings"
 
 	"backend/internal/domain"
+	"backend/internal/logging"
 )
 
 type TVShowUseCase struct {
-	tvShowRepo  domain.TVShowRepository
-	tmdbService domain.TMDBService
+	tvShowRepo   domain.TVShowRepository
+	overrideRepo domain.OverrideRepository
+	tmdbService  domain.TMDBService
+	storage      domain.StorageStatus
 }
 
-func NewTVShowUseCase(tvShowRepo domain.TVShowRepository, tmdbService domain.TMDBService) *TVShowUseCase {
+func NewTVShowUseCase(tvShowRepo domain.TVShowRepository, overrideRepo domain.OverrideRepository, tmdbService domain.TMDBService, storage domain.StorageStatus) *TVShowUseCase {
 	return &TVShowUseCase{
-		tvShowRepo:  tvShowRepo,
-		tmdbService: tmdbService,
+		tvShowRepo:   tvShowRepo,
+		overrideRepo: overrideRepo,
+		tmdbService:  tmdbService,
+		storage:      storage,
 	}
 }
 
 func (uc *TVShowUseCase) GetTVShowByID(ctx context.Context, id string) (*domain.TVShow, error) {
+	ctx, span := tracer.Start(ctx, "TVShowUseCase.GetTVShowByID")
+	defer span.End()
+
 	tvShow, err := uc.tvShowRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, ErrTVShowNotFound
 	}
-	return tvShow, nil
+	return uc.visible(ctx, tvShow)
 }
 
 func (uc *TVShowUseCase) GetTVShowByTMDBID(ctx context.Context, tmdbID int) (*domain.TVShow, error) {
+	ctx, span := tracer.Start(ctx, "TVShowUseCase.GetTVShowByTMDBID")
+	defer span.End()
+
+	// Without a database, serve TMDB's copy as is
+	if !uc.storage.Available() {
+		tmdbTVShow, err := uc.tmdbService.GetTVShow(ctx, tmdbID)
+		if err != nil {
+			return nil, ErrTVShowNotFound
+		}
+		return tmdbTVShow, nil
+	}
+
 	// First check if we have it in our database
 	tvShow, err := uc.tvShowRepo.GetByTMDBID(ctx, tmdbID)
 	if err == nil {
-		return tvShow, nil
+		return uc.visible(ctx, tvShow)
 	}
 
 	// If not found locally, fetch from TMDB and store
@@ -40,17 +60,31 @@ func (uc *TVShowUseCase) GetTVShowByTMDBID(ctx context.Context, tmdbID int) (*do
 		return nil, ErrTVShowNotFound
 	}
 
-	// Save to our database
-	tmdbTVShow.CreatedAt = time.Now()
-	tmdbTVShow.UpdatedAt = time.Now()
-	if err := uc.tvShowRepo.Create(ctx, tmdbTVShow); err != nil {
-		// Log error but return the TV show anyway
+	// Curated values are stored too, as in storeTVShows; without the
+	// override, storing would overwrite them with upstream ones
+	override, err := uc.overrideRepo.Get(ctx, "tv", tmdbID)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to load catalog override, not storing TV show fetched from TMDB", "tmdb_id", tmdbID, "error", err)
+		return tmdbTVShow, nil
+	}
+	tmdbTVShow.ApplyOverride(override)
+
+	// Save to our database, serving the TV show anyway if that fails
+	if err := uc.tvShowRepo.Upsert(ctx, tmdbTVShow); err != nil {
+		logging.FromContext(ctx).Warn("Failed to store TV show fetched from TMDB", "tmdb_id", tmdbID, "error", err)
 	}
 
+	// The stored copy may have been hidden or deleted
+	if tmdbTVShow.Hidden || tmdbTVShow.DeletedAt != nil {
+		return nil, ErrTVShowNotFound
+	}
 	return tmdbTVShow, nil
 }
 
 func (uc *TVShowUseCase) SearchTVShows(ctx context.Context, query string, page int) ([]*domain.TVShow, int, error) {
+	ctx, span := tracer.Start(ctx, "TVShowUseCase.SearchTVShows")
+	defer span.End()
+
 	if query == "" {
 		return nil, 0, ErrInvalidInput
 	}
@@ -61,51 +95,109 @@ func (uc *TVShowUseCase) SearchTVShows(ctx context.Context, query string, page i
 		return nil, 0, err
 	}
 
-	// Store in our database for future reference
-	for _, tvShow := range tvShows {
-		tvShow.CreatedAt = time.Now()
-		tvShow.UpdatedAt = time.Now()
-		// Check if exists first
-		if _, err := uc.tvShowRepo.GetByTMDBID(ctx, tvShow.TMDBTVShowID); err != nil {
-			uc.tvShowRepo.Create(ctx, tvShow) // Ignore errors for now
-		}
-	}
-
-	return tvShows, totalPages, nil
+	return uc.storeTVShows(ctx, tvShows), totalPages, nil
 }
 
 func (uc *TVShowUseCase) GetPopularTVShows(ctx context.Context, page int) ([]*domain.TVShow, int, error) {
+	ctx, span := tracer.Start(ctx, "TVShowUseCase.GetPopularTVShows")
+	defer span.End()
+
 	tvShows, totalPages, err := uc.tmdbService.GetPopularTVShows(ctx, page)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	// Store in our database
-	for _, tvShow := range tvShows {
-		tvShow.CreatedAt = time.Now()
-		tvShow.UpdatedAt = time.Now()
-		if _, err := uc.tvShowRepo.GetByTMDBID(ctx, tvShow.TMDBTVShowID); err != nil {
-			uc.tvShowRepo.Create(ctx, tvShow)
-		}
-	}
-
-	return tvShows, totalPages, nil
+	return uc.storeTVShows(ctx, tvShows), totalPages, nil
 }
 
 func (uc *TVShowUseCase) GetTVShowsByGenre(ctx context.Context, genreID int, page int) ([]*domain.TVShow, int, error) {
+	ctx, span := tracer.Start(ctx, "TVShowUseCase.GetTVShowsByGenre")
+	defer span.End()
+
 	tvShows, totalPages, err := uc.tmdbService.GetTVShowsByGenre(ctx, genreID, page)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	// Store in our database
+	return uc.storeTVShows(ctx, tvShows), totalPages, nil
+}
+
+// SearchCatalog searches TV shows already stored locally, best matches first.
+func (uc *TVShowUseCase) SearchCatalog(ctx context.Context, query string, limit, offset int, withTotal bool) ([]*domain.TVShowSearchResult, *int64, error) {
+	ctx, span := tracer.Start(ctx, "TVShowUseCase.SearchCatalog")
+	defer span.End()
+
+	if strings.TrimSpace(query) == "" {
+		return nil, nil, ErrInvalidInput
+	}
+	results, total, err := uc.tvShowRepo.Search(ctx, query, limit, offset, withTotal)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tmdbIDs := make([]int, 0, len(results))
+	for _, result := range results {
+		tmdbIDs = append(tmdbIDs, result.TMDBTVShowID)
+	}
+	overrides := loadOverrides(ctx, uc.overrideRepo, "tv", tmdbIDs)
+	for _, result := range results {
+		result.ApplyOverride(overrides[result.TMDBTVShowID])
+	}
+
+	return results, total, nil
+}
+
+// BrowseCatalog lists TV shows already stored locally.
+func (uc *TVShowUseCase) BrowseCatalog(ctx context.Context, query domain.CatalogQuery, page domain.PageRequest) (*domain.Page[*domain.TVShow], error) {
+	ctx, span := tracer.Start(ctx, "TVShowUseCase.BrowseCatalog")
+	defer span.End()
+
+	result, err := uc.tvShowRepo.Browse(ctx, query, page)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Items = mergeTVShows(ctx, uc.overrideRepo, result.Items)
+	return result, nil
+}
+
+// storeTVShows saves TV shows fetched from TMDB for future reference and
+// merges in curated overrides. In degraded mode they are returned as fetched.
+func (uc *TVShowUseCase) storeTVShows(ctx context.Context, tvShows []*domain.TVShow) []*domain.TVShow {
+	if !uc.storage.Available() || len(tvShows) == 0 {
+		return tvShows
+	}
+
+	overrides, err := uc.overrideRepo.GetMany(ctx, "tv", tvShowTMDBIDs(tvShows))
+	if err != nil {
+		// Storing upstream values now would overwrite curated ones
+		logging.FromContext(ctx).Warn("Failed to load catalog overrides, not storing TV shows fetched from TMDB", "count", len(tvShows), "error", err)
+		return overrideTVShows(tvShows, nil)
+	}
+
+	// Curated values are stored too, so local search ranks on them
+	keyed := byTMDBID(overrides)
 	for _, tvShow := range tvShows {
-		tvShow.CreatedAt = time.Now()
-		tvShow.UpdatedAt = time.Now()
-		if _, err := uc.tvShowRepo.GetByTMDBID(ctx, tvShow.TMDBTVShowID); err != nil {
-			uc.tvShowRepo.Create(ctx, tvShow)
-		}
+		tvShow.ApplyOverride(keyed[tvShow.TMDBTVShowID])
+	}
+	if err := uc.tvShowRepo.UpsertMany(ctx, tvShows); err != nil {
+		logging.FromContext(ctx).Warn("Failed to store TV shows fetched from TMDB", "count", len(tvShows), "error", err)
 	}
+	return overrideTVShows(tvShows, keyed)
+}
 
-	return tvShows, totalPages, nil
+// visible hides a title an operator has hidden or deleted and applies its
+// curated override.
+func (uc *TVShowUseCase) visible(ctx context.Context, tvShow *domain.TVShow) (*domain.TVShow, error) {
+	if tvShow.Hidden || tvShow.DeletedAt != nil {
+		return nil, ErrTVShowNotFound
+	}
+
+	override, err := uc.overrideRepo.Get(ctx, "tv", tvShow.TMDBTVShowID)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to load catalog override", "media_type", "tv", "tmdb_id", tvShow.TMDBTVShowID, "error", err)
+	} else {
+		tvShow.ApplyOverride(override)
+	}
+	return tvShow, nil
 }
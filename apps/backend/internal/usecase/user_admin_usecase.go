@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"backend/internal/domain"
+)
+
+// UserAdminUseCase lets operators manage user accounts.
+type UserAdminUseCase struct {
+	userRepo domain.UserRepository
+}
+
+func NewUserAdminUseCase(userRepo domain.UserRepository) *UserAdminUseCase {
+	return &UserAdminUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// DeleteUser soft-deletes a user. Their watchlist and ratings are kept until
+// the account is purged.
+func (uc *UserAdminUseCase) DeleteUser(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "UserAdminUseCase.DeleteUser")
+	defer span.End()
+
+	if err := uc.userRepo.Delete(ctx, id); err != nil {
+		return notFoundAs(id, err, ErrUserNotFound)
+	}
+	return nil
+}
+
+// RestoreUser brings back a soft-deleted user.
+func (uc *UserAdminUseCase) RestoreUser(ctx context.Context, id string) (*domain.User, error) {
+	ctx, span := tracer.Start(ctx, "UserAdminUseCase.RestoreUser")
+	defer span.End()
+
+	if err := uc.userRepo.Restore(ctx, id); err != nil {
+		return nil, notFoundAs(id, err, ErrUserNotFound)
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, notFoundAs(id, err, ErrUserNotFound)
+	}
+	return user, nil
+}
+
+// UserUpdate holds the changes to a user's profile. Empty fields and a nil
+// IsActive are left as they are.
+type UserUpdate struct {
+	Email     string
+	Username  string
+	FirstName string
+	LastName  string
+	Avatar    string
+	IsActive  *bool
+}
+
+func (u UserUpdate) empty() bool {
+	return u == UserUpdate{}
+}
+
+// ListUsers pages through the users that aren't deleted.
+func (uc *UserAdminUseCase) ListUsers(ctx context.Context, page domain.PageRequest) (*domain.Page[*domain.User], error) {
+	ctx, span := tracer.Start(ctx, "UserAdminUseCase.ListUsers")
+	defer span.End()
+
+	return uc.userRepo.List(ctx, page)
+}
+
+// GetUser returns a user that isn't deleted.
+func (uc *UserAdminUseCase) GetUser(ctx context.Context, id string) (*domain.User, error) {
+	ctx, span := tracer.Start(ctx, "UserAdminUseCase.GetUser")
+	defer span.End()
+
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, notFoundAs(id, err, ErrUserNotFound)
+	}
+	return user, nil
+}
+
+// CreateUser stores a new, active user. ErrAlreadyExists is returned when
+// the email or username is taken.
+func (uc *UserAdminUseCase) CreateUser(ctx context.Context, user *domain.User) error {
+	ctx, span := tracer.Start(ctx, "UserAdminUseCase.CreateUser")
+	defer span.End()
+
+	if err := uc.checkUnique(ctx, user); err != nil {
+		return err
+	}
+	user.IsActive = true
+	return uc.userRepo.Create(ctx, user)
+}
+
+// UpdateUser applies update to a user. domain.ErrVersionConflict is returned
+// when the stored user doesn't meet expected, and ErrAlreadyExists when the
+// new email or username is taken.
+func (uc *UserAdminUseCase) UpdateUser(ctx context.Context, id string, expected domain.VersionCondition, update UserUpdate) (*domain.User, error) {
+	ctx, span := tracer.Start(ctx, "UserAdminUseCase.UpdateUser")
+	defer span.End()
+
+	if update.empty() {
+		return nil, ErrInvalidInput
+	}
+
+	user, err := uc.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !expected.Matches(user.Version) {
+		return nil, domain.ErrVersionConflict
+	}
+
+	if update.Email != "" {
+		user.Email = update.Email
+	}
+	if update.Username != "" {
+		user.Username = update.Username
+	}
+	if update.FirstName != "" {
+		user.FirstName = update.FirstName
+	}
+	if update.LastName != "" {
+		user.LastName = update.LastName
+	}
+	if update.Avatar != "" {
+		user.Avatar = update.Avatar
+	}
+	if update.IsActive != nil {
+		user.IsActive = *update.IsActive
+	}
+	if err := uc.checkUnique(ctx, user); err != nil {
+		return nil, err
+	}
+
+	// The update is versioned too, so an edit racing this one still loses
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, notFoundAs(id, err, ErrUserNotFound)
+	}
+	return user, nil
+}
+
+// checkUnique returns ErrAlreadyExists when another user has user's email
+// or username.
+func (uc *UserAdminUseCase) checkUnique(ctx context.Context, user *domain.User) error {
+	lookups := []func(context.Context, string) (*domain.User, error){uc.userRepo.GetByEmail, uc.userRepo.GetByUsername}
+	for i, value := range []string{user.Email, user.Username} {
+		other, err := lookups[i](ctx, value)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if other.ID != user.ID {
+			return ErrAlreadyExists
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/domain"
+	"backend/internal/infrastructure/repository/memory"
+	"backend/internal/usecase"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// brokenUsers fails every soft delete and restore.
+type brokenUsers struct {
+	domain.UserRepository
+}
+
+var errUserWrite = errors.New("user write failed")
+
+func (brokenUsers) Delete(ctx context.Context, id string) error  { return errUserWrite }
+func (brokenUsers) Restore(ctx context.Context, id string) error { return errUserWrite }
+
+func TestUserAdminDeleteAndRestore(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.New()
+	admin := usecase.NewUserAdminUseCase(repos.Users)
+
+	user := &domain.User{Email: "neo@example.com", Username: "neo", IsActive: true}
+	if err := repos.Users.Create(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	if err := admin.DeleteUser(ctx, user.ID.Hex()); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	if err := admin.DeleteUser(ctx, user.ID.Hex()); !errors.Is(err, usecase.ErrUserNotFound) {
+		t.Errorf("deleting twice returned %v, want user not found", err)
+	}
+	restored, err := admin.RestoreUser(ctx, user.ID.Hex())
+	if err != nil {
+		t.Fatalf("RestoreUser: %v", err)
+	}
+	if restored.DeletedAt != nil {
+		t.Errorf("restored user is still deleted at %v", restored.DeletedAt)
+	}
+
+	for _, id := range []string{primitive.NewObjectID().Hex(), "not-an-id"} {
+		if err := admin.DeleteUser(ctx, id); !errors.Is(err, usecase.ErrUserNotFound) {
+			t.Errorf("DeleteUser(%q) returned %v, want user not found", id, err)
+		}
+		if _, err := admin.RestoreUser(ctx, id); !errors.Is(err, usecase.ErrUserNotFound) {
+			t.Errorf("RestoreUser(%q) returned %v, want user not found", id, err)
+		}
+	}
+
+	// Storage failures aren't reported as missing users
+	broken := usecase.NewUserAdminUseCase(brokenUsers{repos.Users})
+	if err := broken.DeleteUser(ctx, user.ID.Hex()); !errors.Is(err, errUserWrite) {
+		t.Errorf("failed DeleteUser returned %v, want the write error", err)
+	}
+	if _, err := broken.RestoreUser(ctx, user.ID.Hex()); !errors.Is(err, errUserWrite) {
+		t.Errorf("failed RestoreUser returned %v, want the write error", err)
+	}
+}
+
+func TestUserAdminUpdateUser(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.New()
+	admin := usecase.NewUserAdminUseCase(repos.Users)
+
+	user := &domain.User{Email: "neo@example.com", Username: "neo", FirstName: "Thomas", LastName: "Anderson"}
+	if err := admin.CreateUser(ctx, user); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if !user.IsActive || user.Version != 1 {
+		t.Fatalf("created user is active %v at version %d", user.IsActive, user.Version)
+	}
+	other := &domain.User{Email: "trinity@example.com", Username: "trinity"}
+	if err := admin.CreateUser(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	if err := admin.CreateUser(ctx, &domain.User{Email: "neo@example.com", Username: "someone"}); !errors.Is(err, usecase.ErrAlreadyExists) {
+		t.Errorf("creating a user with a taken email returned %v, want already exists", err)
+	}
+
+	id := user.ID.Hex()
+	current := domain.VersionCondition{Versions: []int64{user.Version}}
+	updated, err := admin.UpdateUser(ctx, id, current, usecase.UserUpdate{FirstName: "Neo"})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if updated.FirstName != "Neo" || updated.LastName != "Anderson" || updated.Version != 2 {
+		t.Errorf("updated user is %q %q at version %d", updated.FirstName, updated.LastName, updated.Version)
+	}
+
+	// current still names version 1, which the update above replaced
+	if _, err := admin.UpdateUser(ctx, id, current, usecase.UserUpdate{LastName: "Stale"}); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Errorf("update on a stale version returned %v, want a version conflict", err)
+	}
+	if _, err := admin.UpdateUser(ctx, id, domain.VersionCondition{Any: true}, usecase.UserUpdate{Username: "trinity"}); !errors.Is(err, usecase.ErrAlreadyExists) {
+		t.Errorf("taking another user's username returned %v, want already exists", err)
+	}
+	if _, err := admin.UpdateUser(ctx, id, domain.VersionCondition{Any: true}, usecase.UserUpdate{}); !errors.Is(err, usecase.ErrInvalidInput) {
+		t.Errorf("empty update returned %v, want invalid input", err)
+	}
+	inactive := false
+	if updated, err = admin.UpdateUser(ctx, id, domain.VersionCondition{Any: true}, usecase.UserUpdate{IsActive: &inactive}); err != nil || updated.IsActive {
+		t.Errorf("deactivating returned %+v, %v", updated, err)
+	}
+
+	stored, err := admin.GetUser(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.LastName != "Anderson" || stored.Username != "neo" || stored.Version != 3 {
+		t.Errorf("stored user is %q / %q at version %d after rejected updates", stored.LastName, stored.Username, stored.Version)
+	}
+}
@@ -27,11 +27,17 @@ func NewWatchlistUseCase(
 	}
 }
 
-func (uc *WatchlistUseCase) GetUserWatchlist(ctx context.Context, userID string, limit, offset int) ([]*domain.Watchlist, int64, error) {
-	return uc.watchlistRepo.GetByUserID(ctx, userID, limit, offset)
+func (uc *WatchlistUseCase) GetUserWatchlist(ctx context.Context, userID string, page domain.PageRequest) (*domain.Page[*domain.Watchlist], error) {
+	ctx, span := tracer.Start(ctx, "WatchlistUseCase.GetUserWatchlist")
+	defer span.End()
+
+	return uc.watchlistRepo.GetByUserID(ctx, userID, page)
 }
 
 func (uc *WatchlistUseCase) AddToWatchlist(ctx context.Context, userID, itemID, itemType string) error {
+	ctx, span := tracer.Start(ctx, "WatchlistUseCase.AddToWatchlist")
+	defer span.End()
+
 	// Check if item exists
 	if itemType == "movie" {
 		if _, err := uc.movieRepo.GetByID(ctx, itemID); err != nil {
@@ -45,6 +51,11 @@ func (uc *WatchlistUseCase) AddToWatchlist(ctx context.Context, userID, itemID,
 		return ErrInvalidInput
 	}
 
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return ErrInvalidInput
+	}
+
 	// Check if already in watchlist
 	exists, err := uc.watchlistRepo.IsInWatchlist(ctx, userID, itemID, itemType)
 	if err != nil {
@@ -57,7 +68,7 @@ func (uc *WatchlistUseCase) AddToWatchlist(ctx context.Context, userID, itemID,
 	// Create watchlist item
 	watchlist := &domain.Watchlist{
 		ID:      primitive.NewObjectID(),
-		UserID:  primitive.ObjectID{}, // Convert userID string to ObjectID
+		UserID:  userObjectID,
 		Type:    itemType,
 		AddedAt: time.Now(),
 	}
@@ -74,6 +85,9 @@ func (uc *WatchlistUseCase) AddToWatchlist(ctx context.Context, userID, itemID,
 }
 
 func (uc *WatchlistUseCase) RemoveFromWatchlist(ctx context.Context, userID, itemID, itemType string) error {
+	ctx, span := tracer.Start(ctx, "WatchlistUseCase.RemoveFromWatchlist")
+	defer span.End()
+
 	// Check if in watchlist
 	exists, err := uc.watchlistRepo.IsInWatchlist(ctx, userID, itemID, itemType)
 	if err != nil {
@@ -87,5 +101,8 @@ func (uc *WatchlistUseCase) RemoveFromWatchlist(ctx context.Context, userID, ite
 }
 
 func (uc *WatchlistUseCase) IsInWatchlist(ctx context.Context, userID, itemID, itemType string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "WatchlistUseCase.IsInWatchlist")
+	defer span.End()
+
 	return uc.watchlistRepo.IsInWatchlist(ctx, userID, itemID, itemType)
 }
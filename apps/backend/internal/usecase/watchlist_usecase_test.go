@@ -0,0 +1,45 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/domain"
+	"backend/internal/infrastructure/repository/memory"
+	"backend/internal/usecase"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAddToWatchlist(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.New()
+	uc := usecase.NewWatchlistUseCase(repos.Watchlists, repos.Movies, repos.TVShows)
+
+	movie := &domain.Movie{TMDBMovieID: 603, Title: "The Matrix"}
+	if err := repos.Movies.Create(ctx, movie); err != nil {
+		t.Fatal(err)
+	}
+	userID := primitive.NewObjectID().Hex()
+
+	if err := uc.AddToWatchlist(ctx, userID, movie.ID.Hex(), "movie"); err != nil {
+		t.Fatalf("AddToWatchlist: %v", err)
+	}
+	if err := uc.AddToWatchlist(ctx, userID, movie.ID.Hex(), "movie"); !errors.Is(err, usecase.ErrAlreadyExists) {
+		t.Errorf("adding twice returned %v, want already exists", err)
+	}
+	if err := uc.AddToWatchlist(ctx, userID, primitive.NewObjectID().Hex(), "movie"); !errors.Is(err, usecase.ErrMovieNotFound) {
+		t.Errorf("adding a missing movie returned %v, want movie not found", err)
+	}
+	if err := uc.AddToWatchlist(ctx, userID, movie.ID.Hex(), "book"); !errors.Is(err, usecase.ErrInvalidInput) {
+		t.Errorf("adding an unknown item type returned %v, want invalid input", err)
+	}
+
+	if err := repos.Movies.Delete(ctx, movie.ID.Hex()); err != nil {
+		t.Fatal(err)
+	}
+	if err := uc.AddToWatchlist(ctx, primitive.NewObjectID().Hex(), movie.ID.Hex(), "movie"); !errors.Is(err, usecase.ErrMovieNotFound) {
+		t.Errorf("adding a deleted movie returned %v, want movie not found", err)
+	}
+}
@@ -0,0 +1,342 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"backend/internal/domain"
+)
+
+// maxChangesWindow is the longest date range TMDB's changes API accepts.
+const maxChangesWindow = 14 * 24 * time.Hour
+
+// changeFeeds are the TMDB change feeds the sync job follows.
+var changeFeeds = []string{"movie", "tv", "person"}
+
+type ChangesSyncConfig struct {
+	// Interval is the pause between two sync runs.
+	Interval time.Duration
+	// InitialLookback is how far back the first run starts when no
+	// checkpoint has been stored yet.
+	InitialLookback time.Duration
+	// RateLimit is the TMDB request budget in requests per second.
+	RateLimit float64
+}
+
+// SyncProgress is a snapshot of what the changes sync is doing.
+type SyncProgress struct {
+	Running    bool                 `json:"running"`
+	Runs       int                  `json:"runs"`
+	Feed       string               `json:"feed,omitempty"`
+	Changed    int                  `json:"changed"`
+	Held       int                  `json:"held"`
+	Updated    int                  `json:"updated"`
+	Failed     int                  `json:"failed"`
+	Checkpoint map[string]time.Time `json:"checkpoint"`
+	LastRunAt  *time.Time           `json:"lastRunAt,omitempty"`
+	NextRunAt  *time.Time           `json:"nextRunAt,omitempty"`
+	LastError  string               `json:"lastError,omitempty"`
+}
+
+// ChangesSync follows TMDB's changes feeds from the last stored checkpoint
+// and refreshes only the titles and people we already hold.
+type ChangesSync struct {
+	movieRepo   domain.MovieRepository
+	tvShowRepo  domain.TVShowRepository
+	people      domain.PersonRepository
+	overrides   domain.OverrideRepository
+	checkpoints domain.CheckpointRepository
+	tmdbService domain.TMDBService
+	cfg         ChangesSyncConfig
+	trigger     chan struct{}
+
+	mu       sync.RWMutex
+	progress SyncProgress
+}
+
+func NewChangesSync(
+	movieRepo domain.MovieRepository,
+	tvShowRepo domain.TVShowRepository,
+	people domain.PersonRepository,
+	overrides domain.OverrideRepository,
+	checkpoints domain.CheckpointRepository,
+	tmdbService domain.TMDBService,
+	cfg ChangesSyncConfig,
+) *ChangesSync {
+	return &ChangesSync{
+		movieRepo:   movieRepo,
+		tvShowRepo:  tvShowRepo,
+		people:      people,
+		overrides:   overrides,
+		checkpoints: checkpoints,
+		tmdbService: tmdbService,
+		cfg:         cfg,
+		trigger:     make(chan struct{}, 1),
+		progress:    SyncProgress{Checkpoint: map[string]time.Time{}},
+	}
+}
+
+// Run syncs on every interval, or sooner when triggered, until ctx is
+// cancelled.
+func (s *ChangesSync) Run(ctx context.Context) {
+	limiter := newRateLimiter(s.cfg.RateLimit)
+	defer limiter.Stop()
+
+	for {
+		if err := s.syncOnce(ctx, limiter); err != nil && ctx.Err() == nil {
+			slog.Error("TMDB changes sync failed", "error", err)
+			s.setError(err)
+		}
+
+		next := time.Now().Add(s.cfg.Interval)
+		s.mu.Lock()
+		s.progress.Running = false
+		s.progress.Feed = ""
+		s.progress.NextRunAt = &next
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.trigger:
+		case <-time.After(s.cfg.Interval):
+		}
+	}
+}
+
+// Trigger asks the running job to start a sync now. It returns false when a
+// run is already pending.
+func (s *ChangesSync) Trigger() bool {
+	select {
+	case s.trigger <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Progress returns a copy of the current sync progress.
+func (s *ChangesSync) Progress() SyncProgress {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	progress := s.progress
+	progress.Checkpoint = make(map[string]time.Time, len(s.progress.Checkpoint))
+	for feed, at := range s.progress.Checkpoint {
+		progress.Checkpoint[feed] = at
+	}
+	return progress
+}
+
+func (s *ChangesSync) syncOnce(ctx context.Context, limiter *rateLimiter) error {
+	s.mu.Lock()
+	s.progress = SyncProgress{
+		Running:    true,
+		Runs:       s.progress.Runs + 1,
+		Checkpoint: s.progress.Checkpoint,
+		LastRunAt:  s.progress.LastRunAt,
+	}
+	s.mu.Unlock()
+
+	for _, feed := range changeFeeds {
+		if err := s.syncFeed(ctx, limiter, feed); err != nil {
+			return err
+		}
+	}
+
+	finished := time.Now()
+	s.mu.Lock()
+	s.progress.LastRunAt = &finished
+	s.mu.Unlock()
+	return nil
+}
+
+// syncFeed walks one changes feed from its checkpoint up to now in windows
+// TMDB accepts, saving the checkpoint after each completed window.
+func (s *ChangesSync) syncFeed(ctx context.Context, limiter *rateLimiter, feed string) error {
+	s.mu.Lock()
+	s.progress.Feed = feed
+	s.mu.Unlock()
+
+	name := "tmdb_changes:" + feed
+	checkpoint, err := s.checkpoints.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if checkpoint == nil {
+		checkpoint = &domain.Checkpoint{
+			Name:          name,
+			SyncedThrough: now.Add(-s.cfg.InitialLookback),
+		}
+	}
+
+	for start := checkpoint.SyncedThrough; start.Before(now); {
+		end := start.Add(maxChangesWindow)
+		if end.After(now) {
+			end = now
+		}
+
+		if err := s.syncWindow(ctx, limiter, feed, start, end); err != nil {
+			return err
+		}
+
+		checkpoint.SyncedThrough = end
+		if err := s.checkpoints.Save(ctx, checkpoint); err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		s.progress.Checkpoint[feed] = end
+		s.mu.Unlock()
+		start = end
+	}
+
+	return nil
+}
+
+func (s *ChangesSync) syncWindow(ctx context.Context, limiter *rateLimiter, feed string, start, end time.Time) error {
+	for page, totalPages := 1, 1; page <= totalPages; page++ {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		ids, pages, err := s.tmdbService.GetChanges(ctx, feed, start, end, page)
+		if err != nil {
+			return err
+		}
+		totalPages = pages
+
+		s.mu.Lock()
+		s.progress.Changed += len(ids)
+		s.mu.Unlock()
+
+		switch feed {
+		case "movie":
+			err = s.refreshMovies(ctx, limiter, ids)
+		case "tv":
+			err = s.refreshTVShows(ctx, limiter, ids)
+		case "person":
+			err = s.refreshPeople(ctx, limiter, ids)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ChangesSync) refreshMovies(ctx context.Context, limiter *rateLimiter, tmdbIDs []int) error {
+	if len(tmdbIDs) == 0 {
+		return nil
+	}
+
+	movies, err := s.movieRepo.GetByTMDBIDs(ctx, tmdbIDs)
+	if err != nil {
+		return err
+	}
+	s.addHeld(len(movies))
+
+	for _, movie := range movies {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		upstream, err := s.tmdbService.GetMovie(ctx, movie.TMDBMovieID)
+		if err == nil {
+			err = storeMovie(ctx, s.movieRepo, s.overrides, movie, upstream)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		s.record(err)
+	}
+
+	return nil
+}
+
+func (s *ChangesSync) refreshTVShows(ctx context.Context, limiter *rateLimiter, tmdbIDs []int) error {
+	if len(tmdbIDs) == 0 {
+		return nil
+	}
+
+	tvShows, err := s.tvShowRepo.GetByTMDBIDs(ctx, tmdbIDs)
+	if err != nil {
+		return err
+	}
+	s.addHeld(len(tvShows))
+
+	for _, tvShow := range tvShows {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		upstream, err := s.tmdbService.GetTVShow(ctx, tvShow.TMDBTVShowID)
+		if err == nil {
+			err = storeTVShow(ctx, s.tvShowRepo, s.overrides, tvShow, upstream)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		s.record(err)
+	}
+
+	return nil
+}
+
+func (s *ChangesSync) refreshPeople(ctx context.Context, limiter *rateLimiter, tmdbIDs []int) error {
+	if len(tmdbIDs) == 0 {
+		return nil
+	}
+
+	people, err := s.people.GetByTMDBIDs(ctx, tmdbIDs)
+	if err != nil {
+		return err
+	}
+	s.addHeld(len(people))
+
+	for _, person := range people {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		upstream, err := s.tmdbService.GetPerson(ctx, person.TMDBPersonID)
+		if err == nil {
+			err = s.people.Upsert(ctx, upstream)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		s.record(err)
+	}
+
+	return nil
+}
+
+func (s *ChangesSync) addHeld(n int) {
+	s.mu.Lock()
+	s.progress.Held += n
+	s.mu.Unlock()
+}
+
+func (s *ChangesSync) setError(err error) {
+	s.mu.Lock()
+	s.progress.LastError = err.Error()
+	s.mu.Unlock()
+}
+
+func (s *ChangesSync) record(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		s.progress.Failed++
+		s.progress.LastError = err.Error()
+		return
+	}
+	s.progress.Updated++
+}
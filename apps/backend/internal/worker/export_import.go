@@ -0,0 +1,278 @@
+package worker
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"backend/internal/domain"
+)
+
+type ExportImportConfig struct {
+	// MediaType is "movie" or "tv", matching the export file being read.
+	MediaType string
+	// MinPopularity drops entries less popular than this.
+	MinPopularity float64
+	// IncludeAdult keeps entries flagged as adult content.
+	IncludeAdult bool
+	// Concurrency bounds the number of in-flight TMDB fetches.
+	Concurrency int
+	// RateLimit is the TMDB request budget in requests per second.
+	RateLimit float64
+	// BatchSize is how many export lines are processed between checkpoints.
+	BatchSize int
+}
+
+// ImportStats summarises an export import run.
+type ImportStats struct {
+	Lines    int64 `json:"lines"`
+	Matched  int   `json:"matched"`
+	Held     int   `json:"held"`
+	Imported int   `json:"imported"`
+	Failed   int   `json:"failed"`
+}
+
+// exportEntry is one line of a TMDB daily ID export file.
+type exportEntry struct {
+	ID         int     `json:"id"`
+	Adult      bool    `json:"adult"`
+	Popularity float64 `json:"popularity"`
+}
+
+// exportTitle is a matched export entry and the line it was read from.
+type exportTitle struct {
+	TMDBID int
+	Line   int64
+}
+
+// ExportImporter hydrates titles listed in a TMDB daily ID export into the
+// local catalog. Progress is checkpointed per batch so an interrupted import
+// resumes where it stopped; the checkpoint never moves past a title that
+// failed to import, so a rerun retries it.
+type ExportImporter struct {
+	movieRepo   domain.MovieRepository
+	tvShowRepo  domain.TVShowRepository
+	checkpoints domain.CheckpointRepository
+	tmdbService domain.TMDBService
+	cfg         ExportImportConfig
+}
+
+func NewExportImporter(
+	movieRepo domain.MovieRepository,
+	tvShowRepo domain.TVShowRepository,
+	checkpoints domain.CheckpointRepository,
+	tmdbService domain.TMDBService,
+	cfg ExportImportConfig,
+) *ExportImporter {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 500
+	}
+	return &ExportImporter{
+		movieRepo:   movieRepo,
+		tvShowRepo:  tvShowRepo,
+		checkpoints: checkpoints,
+		tmdbService: tmdbService,
+		cfg:         cfg,
+	}
+}
+
+// Import reads the gzipped export at path, skipping lines already covered
+// by a stored checkpoint, and stores every matching title we don't hold yet.
+func (im *ExportImporter) Import(ctx context.Context, path string) (ImportStats, error) {
+	var stats ImportStats
+	if im.cfg.MediaType != "movie" && im.cfg.MediaType != "tv" {
+		return stats, fmt.Errorf("unsupported media type %q", im.cfg.MediaType)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return stats, err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return stats, err
+	}
+	defer gz.Close()
+
+	name := fmt.Sprintf("tmdb_export:%s:%s", im.cfg.MediaType, filepath.Base(path))
+	checkpoint, err := im.checkpoints.Get(ctx, name)
+	if err != nil {
+		return stats, err
+	}
+	if checkpoint == nil {
+		checkpoint = &domain.Checkpoint{Name: name}
+	} else {
+		slog.Info("Resuming import", "media_type", im.cfg.MediaType, "after_line", checkpoint.Offset)
+	}
+
+	limiter := newRateLimiter(im.cfg.RateLimit)
+	defer limiter.Stop()
+
+	scanner := bufio.NewScanner(gz)
+	batch := make([]exportTitle, 0, im.cfg.BatchSize)
+	var line, firstFailed int64
+	for {
+		more := scanner.Scan()
+		if more {
+			line++
+			if line <= checkpoint.Offset {
+				continue
+			}
+			stats.Lines++
+
+			var entry exportEntry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				stats.Failed++
+				continue
+			}
+			if entry.Popularity >= im.cfg.MinPopularity && (im.cfg.IncludeAdult || !entry.Adult) {
+				batch = append(batch, exportTitle{TMDBID: entry.ID, Line: line})
+			}
+			if stats.Lines%int64(im.cfg.BatchSize) != 0 {
+				continue
+			}
+		}
+
+		failed, err := im.importBatch(ctx, limiter, batch, &stats)
+		if err != nil {
+			return stats, err
+		}
+		batch = batch[:0]
+		if firstFailed == 0 {
+			firstFailed = failed
+		}
+
+		offset := line
+		if firstFailed > 0 {
+			offset = firstFailed - 1
+		}
+		if offset > checkpoint.Offset {
+			checkpoint.Offset = offset
+			if err := im.checkpoints.Save(ctx, checkpoint); err != nil {
+				return stats, err
+			}
+		}
+
+		if !more {
+			break
+		}
+		slog.Info("Imported batch", "through_line", line, "stats", stats)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+// importBatch fetches and stores the titles in batch that aren't held yet,
+// running at most cfg.Concurrency fetches at once. It returns the earliest
+// line whose title failed to import, or 0 if none did.
+func (im *ExportImporter) importBatch(ctx context.Context, limiter *rateLimiter, batch []exportTitle, stats *ImportStats) (int64, error) {
+	if len(batch) == 0 {
+		return 0, nil
+	}
+	stats.Matched += len(batch)
+
+	tmdbIDs := make([]int, len(batch))
+	for i, title := range batch {
+		tmdbIDs[i] = title.TMDBID
+	}
+
+	held, err := im.heldIDs(ctx, tmdbIDs)
+	if err != nil {
+		return 0, err
+	}
+	stats.Held += len(held)
+
+	var (
+		wg          sync.WaitGroup
+		mu          sync.Mutex
+		firstFailed int64
+		sem         = make(chan struct{}, im.cfg.Concurrency)
+	)
+	for _, title := range batch {
+		if held[title.TMDBID] {
+			continue
+		}
+		if err := limiter.Wait(ctx); err != nil {
+			break
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(title exportTitle) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			err := im.importOne(ctx, title.TMDBID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				stats.Failed++
+				if firstFailed == 0 || title.Line < firstFailed {
+					firstFailed = title.Line
+				}
+				slog.Warn("Failed to import title", "media_type", im.cfg.MediaType, "tmdb_id", title.TMDBID, "error", err)
+				return
+			}
+			stats.Imported++
+		}(title)
+	}
+	wg.Wait()
+
+	return firstFailed, ctx.Err()
+}
+
+func (im *ExportImporter) heldIDs(ctx context.Context, tmdbIDs []int) (map[int]bool, error) {
+	held := make(map[int]bool)
+	if im.cfg.MediaType == "movie" {
+		movies, err := im.movieRepo.GetByTMDBIDs(ctx, tmdbIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, movie := range movies {
+			held[movie.TMDBMovieID] = true
+		}
+		return held, nil
+	}
+
+	tvShows, err := im.tvShowRepo.GetByTMDBIDs(ctx, tmdbIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, tvShow := range tvShows {
+		held[tvShow.TMDBTVShowID] = true
+	}
+	return held, nil
+}
+
+func (im *ExportImporter) importOne(ctx context.Context, tmdbID int) error {
+	if im.cfg.MediaType == "movie" {
+		movie, err := im.tmdbService.GetMovie(ctx, tmdbID)
+		if err != nil {
+			return err
+		}
+		return im.movieRepo.Upsert(ctx, movie)
+	}
+
+	tvShow, err := im.tmdbService.GetTVShow(ctx, tmdbID)
+	if err != nil {
+		return err
+	}
+	return im.tvShowRepo.Upsert(ctx, tvShow)
+}
@@ -0,0 +1,32 @@
+package worker
+
+import (
+	"context"
+
+	"backend/internal/domain"
+)
+
+// storeMovie copies upstream data onto a stored movie, puts its curated
+// override back on top and saves it, so a refresh never loses operator edits.
+func storeMovie(ctx context.Context, repo domain.MovieRepository, overrides domain.OverrideRepository, movie, upstream *domain.Movie) error {
+	override, err := overrides.Get(ctx, "movie", movie.TMDBMovieID)
+	if err != nil {
+		return err
+	}
+
+	movie.ApplyUpstream(upstream)
+	movie.ApplyOverride(override)
+	return repo.Update(ctx, movie)
+}
+
+// storeTVShow is storeMovie for TV shows.
+func storeTVShow(ctx context.Context, repo domain.TVShowRepository, overrides domain.OverrideRepository, tvShow, upstream *domain.TVShow) error {
+	override, err := overrides.Get(ctx, "tv", tvShow.TMDBTVShowID)
+	if err != nil {
+		return err
+	}
+
+	tvShow.ApplyUpstream(upstream)
+	tvShow.ApplyOverride(override)
+	return repo.Update(ctx, tvShow)
+}
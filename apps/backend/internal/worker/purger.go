@@ -0,0 +1,199 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"backend/internal/domain"
+)
+
+type PurgerConfig struct {
+	// Interval is the pause between two purge passes.
+	Interval time.Duration
+	// Retention is how long soft-deleted records are kept before they are
+	// removed for good.
+	Retention time.Duration
+}
+
+// PurgeProgress is a snapshot of what the purger has done.
+type PurgeProgress struct {
+	Running    bool       `json:"running"`
+	Passes     int        `json:"passes"`
+	Movies     int        `json:"movies"`
+	TVShows    int        `json:"tvShows"`
+	Users      int        `json:"users"`
+	LastPassAt *time.Time `json:"lastPassAt,omitempty"`
+	NextPassAt *time.Time `json:"nextPassAt,omitempty"`
+	LastError  string     `json:"lastError,omitempty"`
+}
+
+// Purger hard-deletes movies, TV shows and users soft-deleted longer ago than
+// the retention window, together with the watchlist and rating entries that
+// reference them and the catalog overrides of purged titles. Records go first
+// and their entries after, so a record restored while a pass runs keeps its
+// entries; entries that fail to go are retried on the next pass.
+type Purger struct {
+	movieRepo     domain.MovieRepository
+	tvShowRepo    domain.TVShowRepository
+	userRepo      domain.UserRepository
+	watchlistRepo domain.WatchlistRepository
+	ratingRepo    domain.RatingRepository
+	overrideRepo  domain.OverrideRepository
+	cfg           PurgerConfig
+
+	// unreferenced holds purged IDs, by item type, whose watchlist and
+	// rating entries are still to be removed, and overridden the TMDB IDs of
+	// purged titles whose overrides are. Only Run touches them.
+	unreferenced map[string][]string
+	overridden   map[string][]int
+
+	mu       sync.RWMutex
+	progress PurgeProgress
+}
+
+func NewPurger(
+	movieRepo domain.MovieRepository,
+	tvShowRepo domain.TVShowRepository,
+	userRepo domain.UserRepository,
+	watchlistRepo domain.WatchlistRepository,
+	ratingRepo domain.RatingRepository,
+	overrideRepo domain.OverrideRepository,
+	cfg PurgerConfig,
+) *Purger {
+	return &Purger{
+		movieRepo:     movieRepo,
+		tvShowRepo:    tvShowRepo,
+		userRepo:      userRepo,
+		watchlistRepo: watchlistRepo,
+		ratingRepo:    ratingRepo,
+		overrideRepo:  overrideRepo,
+		cfg:           cfg,
+		unreferenced:  make(map[string][]string),
+		overridden:    make(map[string][]int),
+	}
+}
+
+// Run purges in passes until ctx is cancelled.
+func (p *Purger) Run(ctx context.Context) {
+	for {
+		if err := p.runPass(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("Purge pass failed", "error", err)
+			p.mu.Lock()
+			p.progress.LastError = err.Error()
+			p.mu.Unlock()
+		}
+
+		next := time.Now().Add(p.cfg.Interval)
+		p.mu.Lock()
+		p.progress.Running = false
+		p.progress.NextPassAt = &next
+		p.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.cfg.Interval):
+		}
+	}
+}
+
+// Progress returns a copy of the current purge progress.
+func (p *Purger) Progress() PurgeProgress {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.progress
+}
+
+func (p *Purger) runPass(ctx context.Context) error {
+	p.mu.Lock()
+	p.progress.Running = true
+	p.progress.Passes++
+	p.mu.Unlock()
+
+	cutoff := time.Now().Add(-p.cfg.Retention)
+
+	movies, err := p.purge(ctx, "movie", p.movieRepo.ListDeleted, p.movieRepo.GetTMDBIDs, p.movieRepo.Purge, cutoff)
+	if err != nil {
+		return err
+	}
+	tvShows, err := p.purge(ctx, "tv", p.tvShowRepo.ListDeleted, p.tvShowRepo.GetTMDBIDs, p.tvShowRepo.Purge, cutoff)
+	if err != nil {
+		return err
+	}
+	users, err := p.purge(ctx, "user", p.userRepo.ListDeleted, nil, p.userRepo.Purge, cutoff)
+	if err != nil {
+		return err
+	}
+
+	finished := time.Now()
+	p.mu.Lock()
+	p.progress.Movies += movies
+	p.progress.TVShows += tvShows
+	p.progress.Users += users
+	p.progress.LastPassAt = &finished
+	p.mu.Unlock()
+	return nil
+}
+
+// purge hard-deletes the records of one type soft-deleted before cutoff,
+// then the entries referencing them. For titles, tmdbIDs looks up the TMDB
+// IDs their overrides are keyed on while the records are still there; it is
+// nil for users. It returns how many records went.
+func (p *Purger) purge(
+	ctx context.Context,
+	itemType string,
+	listDeleted func(context.Context, time.Time) ([]string, error),
+	tmdbIDs func(context.Context, []string) (map[string]int, error),
+	purge func(context.Context, []string, time.Time) ([]string, error),
+	cutoff time.Time,
+) (int, error) {
+	ids, err := listDeleted(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	var byID map[string]int
+	if tmdbIDs != nil {
+		if byID, err = tmdbIDs(ctx, ids); err != nil {
+			return 0, err
+		}
+	}
+	purged, err := purge(ctx, ids, cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, id := range purged {
+		if tmdbID, ok := byID[id]; ok {
+			p.overridden[itemType] = append(p.overridden[itemType], tmdbID)
+		}
+	}
+	p.unreferenced[itemType] = append(p.unreferenced[itemType], purged...)
+	if err := p.removeReferences(ctx, itemType, p.unreferenced[itemType]); err != nil {
+		return len(purged), err
+	}
+	delete(p.unreferenced, itemType)
+	if err := p.overrideRepo.DeleteMany(ctx, itemType, p.overridden[itemType]); err != nil {
+		return len(purged), err
+	}
+	delete(p.overridden, itemType)
+	return len(purged), nil
+}
+
+func (p *Purger) removeReferences(ctx context.Context, itemType string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if itemType == "user" {
+		if err := p.watchlistRepo.RemoveByUsers(ctx, ids); err != nil {
+			return err
+		}
+		return p.ratingRepo.DeleteByUsers(ctx, ids)
+	}
+
+	if err := p.watchlistRepo.RemoveByItems(ctx, ids, itemType); err != nil {
+		return err
+	}
+	return p.ratingRepo.DeleteByItems(ctx, ids, itemType)
+}
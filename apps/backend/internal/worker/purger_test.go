@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend/internal/domain"
+	"backend/internal/infrastructure/repository/memory"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newTestPurger(repos domain.Repositories) *Purger {
+	return NewPurger(repos.Movies, repos.TVShows, repos.Users, repos.Watchlists, repos.Ratings, repos.Overrides, PurgerConfig{Interval: time.Hour})
+}
+
+func TestPurgeRemovesTitleAndItsOverride(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.New()
+
+	purged := &domain.Movie{TMDBMovieID: 603, Title: "The Matrix"}
+	kept := &domain.Movie{TMDBMovieID: 550, Title: "Fight Club"}
+	for _, m := range []*domain.Movie{purged, kept} {
+		if err := repos.Movies.Create(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+		title := m.Title + " (Curated)"
+		if err := repos.Overrides.Save(ctx, &domain.CatalogOverride{MediaType: "movie", TMDBID: m.TMDBMovieID, Fields: domain.OverrideFields{Title: &title}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	userID := primitive.NewObjectID()
+	if err := repos.Watchlists.Add(ctx, &domain.Watchlist{UserID: userID, MovieID: &purged.ID, Type: "movie"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repos.Movies.Delete(ctx, purged.ID.Hex()); err != nil {
+		t.Fatal(err)
+	}
+
+	p := newTestPurger(repos)
+	if err := p.runPass(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Progress().Movies; got != 1 {
+		t.Errorf("purged %d movies, want 1", got)
+	}
+	if err := repos.Movies.Restore(ctx, purged.ID.Hex()); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("restoring a purged movie returned %v, want not found", err)
+	}
+	if override, err := repos.Overrides.Get(ctx, "movie", 603); err != nil || override != nil {
+		t.Errorf("purged movie's override is still stored: %+v, %v", override, err)
+	}
+	if override, err := repos.Overrides.Get(ctx, "movie", 550); err != nil || override == nil {
+		t.Errorf("live movie lost its override: %+v, %v", override, err)
+	}
+	if exists, err := repos.Watchlists.IsInWatchlist(ctx, userID.Hex(), purged.ID.Hex(), "movie"); err != nil || exists {
+		t.Errorf("purged movie is still on a watchlist: %v, %v", exists, err)
+	}
+}
+
+func TestPurgeRemovesUserAndTheirEntries(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.New()
+
+	user := &domain.User{Email: "neo@example.com", Username: "neo", IsActive: true}
+	if err := repos.Users.Create(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	movie := &domain.Movie{TMDBMovieID: 603, Title: "The Matrix"}
+	if err := repos.Movies.Create(ctx, movie); err != nil {
+		t.Fatal(err)
+	}
+	if err := repos.Ratings.Create(ctx, &domain.Rating{UserID: user.ID, MovieID: &movie.ID, Type: "movie", Rating: 9}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repos.Users.Delete(ctx, user.ID.Hex()); err != nil {
+		t.Fatal(err)
+	}
+
+	p := newTestPurger(repos)
+	if err := p.runPass(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Progress().Users; got != 1 {
+		t.Errorf("purged %d users, want 1", got)
+	}
+	if err := repos.Users.Restore(ctx, user.ID.Hex()); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("restoring a purged user returned %v, want not found", err)
+	}
+	if _, err := repos.Ratings.GetByUserAndItem(ctx, user.ID.Hex(), movie.ID.Hex(), "movie"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("purged user's rating returned %v, want not found", err)
+	}
+}
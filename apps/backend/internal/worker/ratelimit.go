@@ -0,0 +1,35 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// rateLimiter spaces calls evenly so a worker stays within a fixed
+// requests-per-second budget against TMDB.
+type rateLimiter struct {
+	ticker *time.Ticker
+}
+
+func newRateLimiter(perSecond float64) *rateLimiter {
+	if perSecond <= 0 {
+		perSecond = 1
+	}
+	return &rateLimiter{
+		ticker: time.NewTicker(time.Duration(float64(time.Second) / perSecond)),
+	}
+}
+
+// Wait blocks until the next call is allowed or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-l.ticker.C:
+		return nil
+	}
+}
+
+func (l *rateLimiter) Stop() {
+	l.ticker.Stop()
+}
@@ -0,0 +1,213 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"backend/internal/domain"
+)
+
+type RefresherConfig struct {
+	// Interval is the pause between two refresh passes.
+	Interval time.Duration
+	// StaleAfter is how old a stored title must be before it is re-fetched.
+	StaleAfter time.Duration
+	// RateLimit is the TMDB request budget in requests per second.
+	RateLimit float64
+	// BatchSize is how many titles are loaded from the database at once.
+	BatchSize int
+}
+
+// RefreshProgress is a snapshot of what the refresher is doing.
+type RefreshProgress struct {
+	Running       bool       `json:"running"`
+	Passes        int        `json:"passes"`
+	Collection    string     `json:"collection,omitempty"`
+	Scanned       int        `json:"scanned"`
+	Updated       int        `json:"updated"`
+	Failed        int        `json:"failed"`
+	PassStartedAt *time.Time `json:"passStartedAt,omitempty"`
+	LastPassAt    *time.Time `json:"lastPassAt,omitempty"`
+	NextPassAt    *time.Time `json:"nextPassAt,omitempty"`
+	LastError     string     `json:"lastError,omitempty"`
+}
+
+// CatalogRefresher walks the stored movies and TV shows oldest-updated
+// first and re-fetches each one from TMDB so local copies don't drift.
+type CatalogRefresher struct {
+	movieRepo   domain.MovieRepository
+	tvShowRepo  domain.TVShowRepository
+	overrides   domain.OverrideRepository
+	tmdbService domain.TMDBService
+	cfg         RefresherConfig
+
+	mu       sync.RWMutex
+	progress RefreshProgress
+}
+
+func NewCatalogRefresher(
+	movieRepo domain.MovieRepository,
+	tvShowRepo domain.TVShowRepository,
+	overrides domain.OverrideRepository,
+	tmdbService domain.TMDBService,
+	cfg RefresherConfig,
+) *CatalogRefresher {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 50
+	}
+	return &CatalogRefresher{
+		movieRepo:   movieRepo,
+		tvShowRepo:  tvShowRepo,
+		overrides:   overrides,
+		tmdbService: tmdbService,
+		cfg:         cfg,
+	}
+}
+
+// Run refreshes the catalog in passes until ctx is cancelled.
+func (r *CatalogRefresher) Run(ctx context.Context) {
+	limiter := newRateLimiter(r.cfg.RateLimit)
+	defer limiter.Stop()
+
+	for {
+		if err := r.runPass(ctx, limiter); err != nil && ctx.Err() == nil {
+			slog.Error("Catalog refresh pass failed", "error", err)
+			r.setError(err)
+		}
+
+		next := time.Now().Add(r.cfg.Interval)
+		r.mu.Lock()
+		r.progress.Running = false
+		r.progress.Collection = ""
+		r.progress.NextPassAt = &next
+		r.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.cfg.Interval):
+		}
+	}
+}
+
+// Progress returns a copy of the current refresh progress.
+func (r *CatalogRefresher) Progress() RefreshProgress {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.progress
+}
+
+func (r *CatalogRefresher) runPass(ctx context.Context, limiter *rateLimiter) error {
+	started := time.Now()
+	r.mu.Lock()
+	r.progress = RefreshProgress{
+		Running:       true,
+		Passes:        r.progress.Passes + 1,
+		PassStartedAt: &started,
+		LastPassAt:    r.progress.LastPassAt,
+	}
+	r.mu.Unlock()
+
+	cutoff := started.Add(-r.cfg.StaleAfter)
+	if err := r.refreshMovies(ctx, limiter, cutoff); err != nil {
+		return err
+	}
+	if err := r.refreshTVShows(ctx, limiter, cutoff); err != nil {
+		return err
+	}
+
+	finished := time.Now()
+	r.mu.Lock()
+	r.progress.LastPassAt = &finished
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *CatalogRefresher) refreshMovies(ctx context.Context, limiter *rateLimiter, cutoff time.Time) error {
+	r.setCollection("movies")
+
+	var after domain.RefreshPosition
+	for {
+		movies, err := r.movieRepo.ListStale(ctx, cutoff, after, r.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(movies) == 0 {
+			return nil
+		}
+
+		for _, movie := range movies {
+			after = domain.RefreshPosition{UpdatedAt: movie.UpdatedAt, ID: movie.ID}
+			if err := limiter.Wait(ctx); err != nil {
+				return err
+			}
+
+			upstream, err := r.tmdbService.GetMovie(ctx, movie.TMDBMovieID)
+			if err == nil {
+				err = storeMovie(ctx, r.movieRepo, r.overrides, movie, upstream)
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			r.record(err)
+		}
+	}
+}
+
+func (r *CatalogRefresher) refreshTVShows(ctx context.Context, limiter *rateLimiter, cutoff time.Time) error {
+	r.setCollection("tv_shows")
+
+	var after domain.RefreshPosition
+	for {
+		tvShows, err := r.tvShowRepo.ListStale(ctx, cutoff, after, r.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(tvShows) == 0 {
+			return nil
+		}
+
+		for _, tvShow := range tvShows {
+			after = domain.RefreshPosition{UpdatedAt: tvShow.UpdatedAt, ID: tvShow.ID}
+			if err := limiter.Wait(ctx); err != nil {
+				return err
+			}
+
+			upstream, err := r.tmdbService.GetTVShow(ctx, tvShow.TMDBTVShowID)
+			if err == nil {
+				err = storeTVShow(ctx, r.tvShowRepo, r.overrides, tvShow, upstream)
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			r.record(err)
+		}
+	}
+}
+
+func (r *CatalogRefresher) setCollection(name string) {
+	r.mu.Lock()
+	r.progress.Collection = name
+	r.mu.Unlock()
+}
+
+func (r *CatalogRefresher) setError(err error) {
+	r.mu.Lock()
+	r.progress.LastError = err.Error()
+	r.mu.Unlock()
+}
+
+func (r *CatalogRefresher) record(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.progress.Scanned++
+	if err != nil {
+		r.progress.Failed++
+		r.progress.LastError = err.Error()
+		return
+	}
+	r.progress.Updated++
+}
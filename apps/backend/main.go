@@ -2,21 +2,41 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"backend/config"
 	"backend/database"
+	"backend/internal/delivery/http/handler"
 	"backend/internal/delivery/http/routes"
+	"backend/internal/domain"
+	"backend/internal/health"
+	"backend/internal/infrastructure/migrations"
+	"backend/internal/infrastructure/repository"
+	"backend/internal/infrastructure/repository/memory"
+	"backend/internal/infrastructure/repository/sqlite"
+	"backend/internal/infrastructure/service"
+	"backend/internal/logging"
 	"backend/internal/middleware"
+	"backend/internal/tracing"
+	"backend/internal/worker"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 // @title Movies & TV Shows API
@@ -36,16 +56,165 @@ import (
 
 func main() {
 	// Load configuration
-	cfg := config.Load()
+	cfg, err := config.LoadArgs(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
-	// Connect to database
-	db, err := database.Connect(cfg.MongoURI)
+	// Log JSON (or text) through slog; the level follows reloads
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(cfg.Runtime().LogLevel)
+	slog.SetDefault(logging.New(os.Stderr, cfg.LogFormat, logLevel))
+	slog.Info("Configuration loaded", "config", cfg)
+
+	// Export traces; pending spans are flushed on shutdown
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg)
 	if err != nil {
-		log.Printf("Warning: Failed to connect to database: %v", err)
-		log.Println("API will continue without database functionality")
-		db = nil
+		fatal("Failed to set up tracing", "error", err)
+	}
+
+	// Background workers and the MongoDB monitor run until shutdown cancels
+	// workerCtx
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+
+	// Connect to the configured storage
+	var repos domain.Repositories
+	var conn *database.Manager
+	// pingStorage backs the readiness probe; the memory driver has nothing
+	// to ping
+	pingStorage := func(context.Context) error { return nil }
+	switch cfg.StorageDriver {
+	case "mongo":
+		conn, err = database.Open(cfg)
+		if err != nil {
+			fatal("Failed to set up MongoDB client", "error", err)
+		}
+		defer conn.Close()
+		pingStorage = conn.Ping
+		repos = repository.New(conn.Database())
+	case "sqlite":
+		sqliteDB, err := sqlite.Open(cfg.SQLitePath)
+		if err != nil {
+			fatal("Failed to open SQLite database", "path", cfg.SQLitePath, "error", err)
+		}
+		defer sqliteDB.Close()
+		pingStorage = sqliteDB.PingContext
+
+		// The embedded schema is always brought up to date; there is no
+		// separate migrate step for a single-binary install
+		migrateCtx, cancelMigrate := context.WithTimeout(context.Background(), 5*time.Minute)
+		applied, err := sqlite.Migrate(migrateCtx, sqliteDB)
+		cancelMigrate()
+		if err != nil {
+			fatal("Schema migration failed", "error", err)
+		}
+		if applied > 0 {
+			slog.Info("Applied SQLite schema migrations", "count", applied)
+		}
+		repos = sqlite.New(sqliteDB)
+	case "memory":
+		slog.Warn("Using in-memory storage, nothing will be persisted")
+		repos = memory.New()
+	default:
+		fatal("Unknown storage driver", "driver", cfg.StorageDriver)
+	}
+
+	var bg routes.Workers
+	var jobs []func(context.Context)
+	tmdbService := service.NewTMDBService(cfg)
+	movieRepo := repos.Movies
+	tvShowRepo := repos.TVShows
+	overrideRepo := repos.Overrides
+
+	if cfg.RefreshEnabled {
+		bg.Refresher = worker.NewCatalogRefresher(movieRepo, tvShowRepo, overrideRepo, tmdbService, worker.RefresherConfig{
+			Interval:   cfg.RefreshInterval,
+			StaleAfter: cfg.RefreshStaleAfter,
+			RateLimit:  cfg.RefreshRateLimit,
+			BatchSize:  cfg.RefreshBatchSize,
+		})
+		jobs = append(jobs, bg.Refresher.Run)
+	}
+
+	if cfg.SyncEnabled {
+		checkpointRepo := repos.Checkpoints
+		bg.ChangesSync = worker.NewChangesSync(movieRepo, tvShowRepo, repos.People, overrideRepo, checkpointRepo, tmdbService, worker.ChangesSyncConfig{
+			Interval:        cfg.SyncInterval,
+			InitialLookback: cfg.SyncInitialLookback,
+			RateLimit:       cfg.SyncRateLimit,
+		})
+		jobs = append(jobs, bg.ChangesSync.Run)
+	}
+
+	if cfg.PurgeEnabled {
+		bg.Purger = worker.NewPurger(
+			movieRepo,
+			tvShowRepo,
+			repos.Users,
+			repos.Watchlists,
+			repos.Ratings,
+			overrideRepo,
+			worker.PurgerConfig{
+				Interval:  cfg.PurgeInterval,
+				Retention: cfg.PurgeRetention,
+			},
+		)
+		jobs = append(jobs, bg.Purger.Run)
+	}
+
+	var workersStarted atomic.Bool
+	startWorkers := func() {
+		workersStarted.Store(true)
+		for _, job := range jobs {
+			workers.Add(1)
+			go func() {
+				defer workers.Done()
+				job(workerCtx)
+			}()
+		}
+	}
+
+	var storage domain.StorageStatus = storageAvailable{}
+	if conn == nil {
+		startWorkers()
+	} else {
+		// Until MongoDB answers the API runs in degraded mode; the schema is
+		// brought up to date and the workers started once it does
+		monitor := database.NewMonitor(conn, database.MonitorConfig{
+			Interval:    cfg.MongoReconnectInterval,
+			MaxInterval: cfg.MongoReconnectMaxInterval,
+			OnConnect: func(ctx context.Context) error {
+				if err := migrateMongo(ctx, conn.Database(), cfg.MigrateOnStartup); err != nil {
+					return err
+				}
+				startWorkers()
+				return nil
+			},
+		})
+		storage = monitor
+
+		checkCtx, cancelCheck := context.WithTimeout(context.Background(), 5*time.Minute)
+		err := monitor.Check(checkCtx)
+		cancelCheck()
+		switch {
+		case errors.Is(err, database.ErrUnreachable):
+			slog.Warn("API will run in degraded mode until MongoDB is reachable", "error", err)
+		case err != nil:
+			fatal("MongoDB setup failed", "error", err)
+		default:
+			slog.Info("Connected to MongoDB", "database", cfg.MongoDatabase)
+		}
+
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			monitor.Run(workerCtx)
+		}()
 	}
-	defer database.Disconnect()
 
 	// Setup router
 	if cfg.Environment == "production" {
@@ -53,13 +222,40 @@ func main() {
 	}
 
 	router := gin.New()
-	router.Use(gin.Logger())
+	// ClientIP, used for logs and rate limits, only believes X-Forwarded-For
+	// from these
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		fatal("Invalid trusted proxies", "error", err)
+	}
+	router.Use(otelgin.Middleware(cfg.ServiceName, otelgin.WithFilter(func(r *http.Request) bool {
+		return r.URL.Path != "/metrics" && r.URL.Path != "/livez" && r.URL.Path != "/readyz"
+	})))
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger())
+	router.Use(middleware.Metrics())
 	router.Use(gin.Recovery())
-	router.Use(middleware.CORS())
+	router.Use(middleware.CORS(cfg))
 
 	// API routes
 	api := router.Group("/api/v1")
-	routes.SetupRoutes(api, db, cfg)
+	routes.SetupRoutes(api, repos, storage, tmdbService, cfg, bg)
+
+	// Probes: liveness never looks at dependencies, readiness checks the
+	// database, TMDB (at most every 30s) and the background workers
+	checker := health.NewChecker(2 * time.Second)
+	checker.Add("database", true, func(ctx context.Context) (any, error) {
+		return map[string]string{"driver": cfg.StorageDriver}, pingStorage(ctx)
+	})
+	checker.Add("tmdb", true, health.Cached(func(ctx context.Context) (any, error) {
+		return nil, tmdbService.Ping(ctx)
+	}, 30*time.Second))
+	checker.Add("workers", false, workersCheck(bg, &workersStarted))
+	healthHandler := handler.NewHealthHandler(checker)
+	router.GET("/livez", healthHandler.Livez)
+	router.GET("/readyz", healthHandler.Readyz)
+
+	// Prometheus metrics
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	// Swagger documentation
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -73,23 +269,84 @@ func main() {
 	// Graceful shutdown
 	go func() {
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Listen: %s\n", err)
+			fatal("Listen failed", "error", err)
 		}
 	}()
 
-	log.Printf("🚀 Server started on port %s", cfg.Port)
-	log.Printf("📖 Swagger docs: http://localhost:%s/swagger/index.html", cfg.Port)
+	slog.Info("Server started",
+		"port", cfg.Port,
+		"swagger", "http://localhost:"+cfg.Port+"/swagger/index.html",
+	)
 
-	// Wait for interrupt signal to gracefully shutdown the server
+	// Reload the reloadable settings on SIGHUP until an interrupt signal
+	// asks for a graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	log.Println("Shutdown Server ...")
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	for waiting := true; waiting; {
+		select {
+		case <-hup:
+			status, err := cfg.Reload(os.Args[1:])
+			if err != nil {
+				slog.Error("Config reload failed", "version", status.Runtime.Version, "error", err)
+				continue
+			}
+			logLevel.Set(status.Runtime.LogLevel)
+			slog.Info("Config reloaded", "version", status.Runtime.Version)
+			if len(status.Restart) > 0 {
+				slog.Warn("Some changes only apply after a restart", "settings", status.Restart)
+			}
+		case <-quit:
+			waiting = false
+		}
+	}
+	slog.Info("Shutting down server")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
+
+	stopWorkers()
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal("Server Shutdown:", err)
+		fatal("Server shutdown failed", "error", err)
+	}
+	workers.Wait()
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Warn("Flushing traces failed", "error", err)
 	}
-	log.Println("Server exiting")
+	slog.Info("Server exiting")
+}
+
+// migrateMongo brings the schema up to date, refusing to run against a newer
+// one. With apply unset pending migrations are only reported.
+func migrateMongo(ctx context.Context, db *mongo.Database, apply bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	runner := migrations.NewRunner(db)
+	var status *migrations.Status
+	var err error
+	if apply {
+		status, err = runner.Up(ctx)
+	} else {
+		status, err = runner.Status(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("schema migration failed: %w", err)
+	}
+	if len(status.Pending) > 0 {
+		slog.Warn("Schema migrations pending, run `go run ./cmd/migrate up`", "count", len(status.Pending))
+	}
+	return nil
+}
+
+// storageAvailable is the status of drivers that are always reachable.
+type storageAvailable struct{}
+
+func (storageAvailable) Available() bool { return true }
+
+// fatal logs msg at error level and exits.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
 }